@@ -19,15 +19,38 @@ type IAuthV1 interface {
 	EditGrouping(ctx context.Context, req *v1.EditGroupingReq) (res *v1.EditGroupingRes, err error)
 	DeleteGrouping(ctx context.Context, req *v1.DeleteGroupingReq) (res *v1.DeleteGroupingRes, err error)
 	FilterGroupings(ctx context.Context, req *v1.FilterGroupingsReq) (res *v1.FilterGroupingsRes, err error)
+	GetAuditLogs(ctx context.Context, req *v1.GetAuditLogsReq) (res *v1.GetAuditLogsRes, err error)
+	CreateSnapshot(ctx context.Context, req *v1.CreateSnapshotReq) (res *v1.CreateSnapshotRes, err error)
+	GetSnapshots(ctx context.Context, req *v1.GetSnapshotsReq) (res *v1.GetSnapshotsRes, err error)
+	DiffSnapshots(ctx context.Context, req *v1.DiffSnapshotsReq) (res *v1.DiffSnapshotsRes, err error)
+	RollbackSnapshot(ctx context.Context, req *v1.RollbackSnapshotReq) (res *v1.RollbackSnapshotRes, err error)
+	ExportRules(ctx context.Context, req *v1.ExportRulesReq) (res *v1.ExportRulesRes, err error)
+	ImportRules(ctx context.Context, req *v1.ImportRulesReq) (res *v1.ImportRulesRes, err error)
+	LintPolicies(ctx context.Context, req *v1.LintPoliciesReq) (res *v1.LintPoliciesRes, err error)
+	SimulatePolicies(ctx context.Context, req *v1.SimulatePoliciesReq) (res *v1.SimulatePoliciesRes, err error)
+	GetAdminAccounts(ctx context.Context, req *v1.GetAdminAccountsReq) (res *v1.GetAdminAccountsRes, err error)
+	AddAdminAccount(ctx context.Context, req *v1.AddAdminAccountReq) (res *v1.AddAdminAccountRes, err error)
+	EditAdminAccount(ctx context.Context, req *v1.EditAdminAccountReq) (res *v1.EditAdminAccountRes, err error)
+	DeleteAdminAccount(ctx context.Context, req *v1.DeleteAdminAccountReq) (res *v1.DeleteAdminAccountRes, err error)
+	GetApiKeys(ctx context.Context, req *v1.GetApiKeysReq) (res *v1.GetApiKeysRes, err error)
+	CreateApiKey(ctx context.Context, req *v1.CreateApiKeyReq) (res *v1.CreateApiKeyRes, err error)
+	RotateApiKey(ctx context.Context, req *v1.RotateApiKeyReq) (res *v1.RotateApiKeyRes, err error)
+	RevokeApiKey(ctx context.Context, req *v1.RevokeApiKeyReq) (res *v1.RevokeApiKeyRes, err error)
 	Check(ctx context.Context, req *v1.CheckReq) (res *v1.CheckRes, err error)
 	CheckAndExplain(ctx context.Context, req *v1.CheckAndExplainReq) (res *v1.CheckAndExplainRes, err error)
+	CheckBatch(ctx context.Context, req *v1.CheckBatchReq) (res *v1.CheckBatchRes, err error)
 	GetAllSubjects(ctx context.Context, req *v1.GetAllSubjectsReq) (res *v1.GetAllSubjectsRes, err error)
 	GetAllObjects(ctx context.Context, req *v1.GetAllObjectsReq) (res *v1.GetAllObjectsRes, err error)
 	GetAllActions(ctx context.Context, req *v1.GetAllActionsReq) (res *v1.GetAllActionsRes, err error)
 	GetAllRoles(ctx context.Context, req *v1.GetAllRolesReq) (res *v1.GetAllRolesRes, err error)
+	GetAllDomains(ctx context.Context, req *v1.GetAllDomainsReq) (res *v1.GetAllDomainsRes, err error)
 	GetAllQuotaPools(ctx context.Context, req *v1.GetAllQuotaPoolsReq) (res *v1.GetAllQuotaPoolsRes, err error)
 	GetAllUsersForQuotaPool(ctx context.Context, req *v1.GetAllUsersForQuotaPoolReq) (res *v1.GetAllUsersForQuotaPoolRes, err error)
 	ChatPreCheckOneStop(ctx context.Context, req *v1.ChatPreCheckOneStopReq) (res *v1.ChatPreCheckOneStopRes, err error)
 	GetAvailableModelForQuotaPool(ctx context.Context, req *v1.GetAvailableModelForQuotaPoolReq) (res *v1.GetAvailableModelForQuotaPoolRes, err error)
 	UniauthLogin(ctx context.Context, req *v1.UniauthLoginReq) (res *v1.UniauthLoginRes, err error)
+	UniauthLogout(ctx context.Context, req *v1.UniauthLogoutReq) (res *v1.UniauthLogoutRes, err error)
+	UniauthMe(ctx context.Context, req *v1.UniauthMeReq) (res *v1.UniauthMeRes, err error)
+	UniauthOidcLogin(ctx context.Context, req *v1.UniauthOidcLoginReq) (res *v1.UniauthOidcLoginRes, err error)
+	UniauthOidcCallback(ctx context.Context, req *v1.UniauthOidcCallbackReq) (res *v1.UniauthOidcCallbackRes, err error)
 }
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// AdminAccountItem 管理员账号，不含密码哈希
+type AdminAccountItem struct {
+	Username    string      `json:"username" orm:"username" dc:"用户名"`
+	DisplayName string      `json:"displayName" orm:"display_name" dc:"显示名称"`
+	Disabled    bool        `json:"disabled" orm:"disabled" dc:"是否禁用"`
+	LastLoginAt *gtime.Time `json:"lastLoginAt" orm:"last_login_at" dc:"最近登录时间"`
+	CreatedAt   *gtime.Time `json:"createdAt" orm:"created_at" dc:"创建时间"`
+}
+
+type GetAdminAccountsReq struct {
+	g.Meta `path:"/admin/accounts" tags:"Auth/Admin/Account" method:"get" summary:"获取所有管理员账号"`
+}
+type GetAdminAccountsRes struct {
+	Items []AdminAccountItem `json:"items" dc:"管理员账号列表"`
+}
+
+type AddAdminAccountReq struct {
+	g.Meta      `path:"/admin/accounts/add" tags:"Auth/Admin/Account" method:"post" summary:"添加管理员账号" dc:"新账号没有任何权限。需要另外通过 Policies 或 Grouping Policies 为 admin:<username> 授权，例如加入 admin_superuser 角色。<br>权限规则的 obj 为接口路径，act 为 HTTP 方法，例如 [\"admin:alice\", \"/auth/admin/*\", \"GET\", \"allow\"]。"`
+	Username    string `json:"username" v:"required|max-length:255" dc:"用户名" example:"alice"`
+	Password    string `json:"password" v:"required|length:8,72" dc:"密码，8 到 72 个字符"`
+	DisplayName string `json:"displayName" v:"max-length:255" dc:"显示名称"`
+}
+type AddAdminAccountRes struct {
+	Ok bool `json:"ok"`
+}
+
+type EditAdminAccountReq struct {
+	g.Meta      `path:"/admin/accounts/edit" tags:"Auth/Admin/Account" method:"post" summary:"编辑管理员账号" dc:"不传的字段不修改。修改密码或禁用账号时，该管理员的所有会话立即失效。"`
+	Username    string  `json:"username" v:"required" dc:"用户名" example:"alice"`
+	Password    *string `json:"password" v:"length:8,72" dc:"新密码，8 到 72 个字符"`
+	DisplayName *string `json:"displayName" v:"max-length:255" dc:"显示名称"`
+	Disabled    *bool   `json:"disabled" dc:"是否禁用"`
+}
+type EditAdminAccountRes struct {
+	Ok bool `json:"ok"`
+}
+
+type DeleteAdminAccountReq struct {
+	g.Meta   `path:"/admin/accounts/delete" tags:"Auth/Admin/Account" method:"post" summary:"删除管理员账号" dc:"同时注销该管理员的所有会话，并删除 admin:<username> 的所有权限规则和角色。"`
+	Username string `json:"username" v:"required" dc:"用户名" example:"alice"`
+}
+type DeleteAdminAccountRes struct {
+	Ok bool `json:"ok"`
+}
@@ -1,17 +1,24 @@
 package v1
 
-import "github.com/gogf/gf/v2/frame/g"
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
 
 type AddPoliciesReq struct {
-	g.Meta   `path:"/admin/policies/add" tags:"Auth/Admin/CRUD" method:"post" summary:"添加 Policies"`
-	Policies [][]string `json:"policies" v:"required" dc:"Policies" example:"[['sub1', 'obj1', 'act1'], ['sub2', 'obj2', 'act2']]"`
-	Skip     bool       `json:"skip" d:"true" dc:"开启时，当规则已经存在时自动跳过，不返回错误；否则会返回错误，并回退所有操作"`
+	g.Meta     `path:"/admin/policies/add" tags:"Auth/Admin/CRUD" method:"post" summary:"添加 Policies" dc:"可以为新添加的规则设置有效期，有效期外的规则不参与鉴权，过期后自动删除。"`
+	Dom        string      `json:"dom" d:"default" dc:"域，不传时为 default 域。规则都在这个域中，请求中的规则不含域字段"`
+	Policies   [][]string  `json:"policies" v:"required" dc:"Policies，每条为 sub, obj, act, eft" example:"[['sub1', 'obj1', 'act1', 'allow'], ['sub2', 'obj2', 'act2', 'allow']]"`
+	Skip       bool        `json:"skip" d:"true" dc:"开启时，当规则已经存在时自动跳过，不返回错误；否则会返回错误，并回退所有操作"`
+	ValidFrom  *gtime.Time `json:"validFrom" dc:"生效时间（含），不传表示立即生效。只作用于本次新添加的规则" example:"2025-09-01 00:00:00"`
+	ValidUntil *gtime.Time `json:"validUntil" v:"after:ValidFrom" dc:"失效时间（不含），不传表示永不过期。只作用于本次新添加的规则" example:"2026-01-31 00:00:00"`
 }
 type AddPoliciesRes struct {
 }
 
 type EditPolicyReq struct {
 	g.Meta    `path:"/admin/policies/edit" tags:"Auth/Admin/CRUD" method:"post" summary:"编辑 Policy" dc:"编辑 Policy。需要提供老的 Policy。<br>注意顺序是 Sub Obj Act。"`
+	Dom       string   `json:"dom" d:"default" dc:"域，不传时为 default 域。规则都在这个域中，请求中的规则不含域字段"`
 	OldPolicy []string `json:"oldPolicy" v:"required" dc:"旧的 Policy" example:"['alice','chat_production','platform','entry']"`
 	NewPolicy []string `json:"newPolicy" v:"required" dc:"新的 Policy" example:"[\"alice\",\"chat_production\",\"platform\",\"entry/no\"]"`
 }
@@ -20,13 +27,15 @@ type EditPolicyRes struct {
 
 type DeletePoliciesReq struct {
 	g.Meta   `path:"/admin/policies/delete" tags:"Auth/Admin/CRUD" method:"post" summary:"删除 Policies" dc:"删除 Policies。原子性操作，当规则中有一条和数据库中的规则不匹配，立即回滚所有操作并返回错误。"`
-	Policies [][]string `json:"policies" v:"required" dc:"Policies" examples:"[['sub1', 'obj1', 'act1'], ['sub2', 'obj2', 'act2']]"`
+	Dom      string     `json:"dom" d:"default" dc:"域，不传时为 default 域。规则都在这个域中，请求中的规则不含域字段"`
+	Policies [][]string `json:"policies" v:"required" dc:"Policies，每条为 sub, obj, act, eft" examples:"[['sub1', 'obj1', 'act1', 'allow'], ['sub2', 'obj2', 'act2', 'allow']]"`
 }
 type DeletePoliciesRes struct {
 }
 
 type FilterPoliciesReq struct {
-	g.Meta   `path:"/admin/policies/filter" tags:"Auth/Admin/Query" method:"post" summary:"筛选 Policies" dc:"模糊匹配。根据给定的条件，返回Policy。留空的字段（传空 Array）将被忽略。"`
+	g.Meta   `path:"/admin/policies/filter" tags:"Auth/Admin/Query" method:"post" summary:"筛选 Policies" dc:"模糊匹配。根据给定的条件，返回域中的Policy，返回的规则不含域字段。留空的字段（传空 Array）将被忽略。"`
+	Dom      string `json:"dom" d:"default" dc:"域，不传时为 default 域"`
 	Sub      string `json:"sub" dc:"Subject"`
 	Obj      string `json:"obj" dc:"Object"`
 	Act      string `json:"act" dc:"Action"`
@@ -45,15 +54,19 @@ type FilterPoliciesRes struct {
 }
 
 type AddGroupingReq struct {
-	g.Meta `path:"/admin/groupings/add" tags:"Auth/Admin/CRUD" method:"post" summary:"添加 Grouping Policies" dc:"允许批量添加 Grouping Policies。"`
-	Groupings [][]string `json:"groupings" v:"required" dc:"Groupings" example:"[['student', 'staff'], ['student', 'staff']]"`
-	Skip     bool       `json:"skip" d:"true" dc:"开启时，当规则已经存在时自动跳过，不返回错误；否则会返回错误，并回退所有操作"`
+	g.Meta     `path:"/admin/groupings/add" tags:"Auth/Admin/CRUD" method:"post" summary:"添加 Grouping Policies" dc:"允许批量添加 Grouping Policies。可以为新添加的继承关系设置有效期，有效期外的继承关系不参与鉴权，过期后自动删除。"`
+	Dom        string      `json:"dom" d:"default" dc:"域，不传时为 default 域。规则都在这个域中，请求中的规则不含域字段"`
+	Groupings  [][]string  `json:"groupings" v:"required" dc:"Groupings" example:"[['student', 'staff'], ['student', 'staff']]"`
+	Skip       bool        `json:"skip" d:"true" dc:"开启时，当规则已经存在时自动跳过，不返回错误；否则会返回错误，并回退所有操作"`
+	ValidFrom  *gtime.Time `json:"validFrom" dc:"生效时间（含），不传表示立即生效。只作用于本次新添加的继承关系。继承关系的判断结果有缓存，由每分钟的定时任务刷新，到达生效时间后最多延迟约 1 分钟生效" example:"2025-09-01 00:00:00"`
+	ValidUntil *gtime.Time `json:"validUntil" v:"after:ValidFrom" dc:"失效时间（不含），不传表示永不过期。只作用于本次新添加的继承关系，例如访问学生使用 VIP 配额池的截止时间。过期的继承关系由每分钟的定时任务删除，到达失效时间后最多延迟约 1 分钟失效" example:"2026-01-31 00:00:00"`
 }
 type AddGroupingRes struct {
 }
 
 type EditGroupingReq struct {
-	g.Meta `path:"/admin/groupings/edit" tags:"Auth/Admin/CRUD" method:"post" summary:"编辑 Grouping Policies" dc:"编辑 Grouping Policies。需要提供老的 Grouping。"`
+	g.Meta      `path:"/admin/groupings/edit" tags:"Auth/Admin/CRUD" method:"post" summary:"编辑 Grouping Policies" dc:"编辑 Grouping Policies。需要提供老的 Grouping。"`
+	Dom         string   `json:"dom" d:"default" dc:"域，不传时为 default 域。规则都在这个域中，请求中的规则不含域字段"`
 	OldGrouping []string `json:"oldGrouping" v:"required" dc:"旧的 Grouping" example:"['student', 'staff']"`
 	NewGrouping []string `json:"newGrouping" v:"required" dc:"新的 Grouping" example:"['student', 'staff']"`
 }
@@ -61,14 +74,16 @@ type EditGroupingRes struct {
 }
 
 type DeleteGroupingReq struct {
-	g.Meta `path:"/admin/groupings/delete" tags:"Auth/Admin/CRUD" method:"post" summary:"删除 Grouping Policies" dc:"允许批量删除 Grouping Policies。原子性操作，当规则中有一条和数据库中的规则不匹配，立即回滚所有操作并返回错误。"`
+	g.Meta    `path:"/admin/groupings/delete" tags:"Auth/Admin/CRUD" method:"post" summary:"删除 Grouping Policies" dc:"允许批量删除 Grouping Policies。原子性操作，当规则中有一条和数据库中的规则不匹配，立即回滚所有操作并返回错误。"`
+	Dom       string     `json:"dom" d:"default" dc:"域，不传时为 default 域。规则都在这个域中，请求中的规则不含域字段"`
 	Groupings [][]string `json:"groupings" v:"required" dc:"Groupings" example:"[['student', 'staff'], ['student', 'staff']]"`
 }
 type DeleteGroupingRes struct {
 }
 
 type FilterGroupingsReq struct {
-	g.Meta   `path:"/admin/groupings/filter" tags:"Auth/Admin/Query" method:"post" summary:"筛选 Grouping Policies" dc:"根据给定的条件，返回 Grouping Policies 角色继承关系。留空的字段（传空 Array）将被忽略。"`
+	g.Meta   `path:"/admin/groupings/filter" tags:"Auth/Admin/Query" method:"post" summary:"筛选 Grouping Policies" dc:"根据给定的条件，返回域中的 Grouping Policies 角色继承关系，返回的规则不含域字段。留空的字段（传空 Array）将被忽略。"`
+	Dom      string `json:"dom" d:"default" dc:"域，不传时为 default 域"`
 	G1       string `json:"g1" dc:"G1 列表"`
 	G2       string `json:"g2" dc:"G2 列表"`
 	Rule     string `json:"rule" dc:"Rule"`
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ApiKeyItem 服务 API Key，不含哈希
+type ApiKeyItem struct {
+	Id          int64       `json:"id" orm:"id" dc:"Key ID"`
+	KeyPrefix   string      `json:"keyPrefix" orm:"key_prefix" dc:"Key 的前几位，用于识别"`
+	Service     string      `json:"service" orm:"service" dc:"服务身份"`
+	Scopes      []string    `json:"scopes" orm:"scopes" dc:"允许调用的路由组"`
+	Description string      `json:"description" orm:"description" dc:"备注"`
+	Status      string      `json:"status" orm:"status" dc:"状态：active | revoked"`
+	ExpiresAt   *gtime.Time `json:"expiresAt" orm:"expires_at" dc:"过期时间"`
+	RotatedFrom int64       `json:"rotatedFrom" orm:"rotated_from" dc:"轮换前的 Key ID"`
+	LastUsedAt  *gtime.Time `json:"lastUsedAt" orm:"last_used_at" dc:"最近使用时间，按分钟粒度更新"`
+	LastUsedIp  string      `json:"lastUsedIp" orm:"last_used_ip" dc:"最近使用的客户端 IP"`
+	CreatedBy   string      `json:"createdBy" orm:"created_by" dc:"创建者"`
+	CreatedAt   *gtime.Time `json:"createdAt" orm:"created_at" dc:"创建时间"`
+	RevokedAt   *gtime.Time `json:"revokedAt" orm:"revoked_at" dc:"吊销时间"`
+}
+
+type GetApiKeysReq struct {
+	g.Meta  `path:"/admin/apiKeys" tags:"Auth/Admin/ApiKey" method:"get" summary:"获取服务 API Key"`
+	Service string `json:"service" dc:"按服务身份过滤，不传则返回所有服务"`
+	Status  string `json:"status" v:"in:active,revoked" dc:"按状态过滤"`
+}
+type GetApiKeysRes struct {
+	Items []ApiKeyItem `json:"items" dc:"Key 列表，按创建时间倒序"`
+}
+
+type CreateApiKeyReq struct {
+	g.Meta      `path:"/admin/apiKeys/create" tags:"Auth/Admin/ApiKey" method:"post" summary:"创建服务 API Key" dc:"调用方通过 X-API-Key 请求头或 Authorization: Bearer <key> 携带 Key。<br>请求路径必须在 Key 的路由组内，且服务身份 svc:<service> 在 Casbin 中有权限，权限规则的 obj 为接口路径，act 为 HTTP 方法，例如 [\"svc:chat-backend\", \"/billing/*\", \"POST\", \"allow\"]。"`
+	Service     string      `json:"service" v:"required|max-length:255|regex:^[A-Za-z0-9._-]+$" dc:"服务身份，只能包含字母、数字、点、下划线和连字符" example:"chat-backend"`
+	Scopes      []string    `json:"scopes" v:"required|foreach|regex:^(\\*|[A-Za-z0-9_]+)$" dc:"允许调用的路由组，例如 [\"billing\"]，[\"*\"] 表示不限制" example:"[\"billing\"]"`
+	Description string      `json:"description" v:"max-length:255" dc:"备注"`
+	ExpiresAt   *gtime.Time `json:"expiresAt" dc:"过期时间，不传表示永不过期"`
+}
+type CreateApiKeyRes struct {
+	Key  string     `json:"key" dc:"Key 明文，只在这里返回一次，请妥善保存"`
+	Item ApiKeyItem `json:"item" dc:"Key 信息"`
+}
+
+type RotateApiKeyReq struct {
+	g.Meta `path:"/admin/apiKeys/rotate" tags:"Auth/Admin/ApiKey" method:"post" summary:"轮换服务 API Key" dc:"生成一个服务身份、路由组和过期时间都相同的新 Key。旧 Key 在宽限期（apiKey.rotationGrace，默认 24 小时）结束时过期，调用方需要在宽限期内切换到新 Key。"`
+	Id     int64 `json:"id" v:"required" dc:"要轮换的 Key ID"`
+}
+type RotateApiKeyRes struct {
+	Key  string     `json:"key" dc:"新 Key 明文，只在这里返回一次，请妥善保存"`
+	Item ApiKeyItem `json:"item" dc:"新 Key 信息"`
+}
+
+type RevokeApiKeyReq struct {
+	g.Meta `path:"/admin/apiKeys/revoke" tags:"Auth/Admin/ApiKey" method:"post" summary:"吊销服务 API Key" dc:"Key 立即失效。"`
+	Id     int64 `json:"id" v:"required" dc:"要吊销的 Key ID"`
+}
+type RevokeApiKeyRes struct {
+	Ok bool `json:"ok"`
+}
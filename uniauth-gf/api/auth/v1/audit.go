@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/model/entity"
+)
+
+type GetAuditLogsReq struct {
+	g.Meta    `path:"/admin/audit" tags:"Auth/Admin/Query" method:"get" summary:"查询权限变更审计日志" dc:"按时间倒序返回 Casbin 规则的变更记录，包括管理接口和配额池等业务流程自动发起的变更。"`
+	Actor     string      `json:"actor" dc:"操作者，精确匹配"`
+	Action    string      `json:"action" v:"in:add,remove,update" dc:"变更类型：add | remove | update"`
+	PType     string      `json:"ptype" v:"in:p,g" dc:"规则类型：p | g"`
+	Operation string      `json:"operation" dc:"发起变更的业务操作，精确匹配，例如 quotaPool.Edit"`
+	RequestId string      `json:"requestId" dc:"请求 ID"`
+	Keyword   string      `json:"keyword" dc:"模糊匹配变更前后的规则内容，例如用户 UPN 或资源名"`
+	StartTime *gtime.Time `json:"startTime" dc:"开始时间（含）" example:"2025-01-01"`
+	EndTime   *gtime.Time `json:"endTime" dc:"结束时间（不含）" example:"2025-02-01"`
+	Page      int         `json:"page" v:"min:1" d:"1" dc:"页码，从1开始"`
+	PageSize  int         `json:"pageSize" v:"min:1|max:1000" d:"20" dc:"每页条数，最大1000"`
+}
+type GetAuditLogsRes struct {
+	Items      []entity.CasbinAuditLog `json:"items" dc:"审计日志列表"`
+	Total      int                     `json:"total" dc:"总记录数"`
+	Page       int                     `json:"page" dc:"当前页码"`
+	PageSize   int                     `json:"pageSize" dc:"每页条数"`
+	TotalPages int                     `json:"totalPages" dc:"总页数"`
+}
@@ -5,8 +5,9 @@ import (
 )
 
 type CheckReq struct {
-	g.Meta `path:"/check" tags:"Auth" method:"post" summary:"基础权限检查" dc:"给定sub obj act，查询是否有权限。"`
+	g.Meta `path:"/check" tags:"Auth" method:"post" summary:"基础权限检查" dc:"给定sub dom obj act，查询是否有权限。"`
 	Sub    string `json:"sub" v:"required" dc:"对象" example:"sadt@cuhk.edu.cn"`
+	Dom    string `json:"dom" d:"default" dc:"域，不传时为 default 域" example:"default"`
 	Obj    string `json:"obj" v:"required" dc:"资源" example:"platform"`
 	Act    string `json:"act" v:"required" dc:"动作" example:"entry"`
 }
@@ -16,44 +17,106 @@ type CheckRes struct {
 }
 
 type CheckAndExplainReq struct {
-	g.Meta `path:"/checkEx" tags:"Auth" method:"post" summary:"解释权限来源" dc:"给定sub obj act，如果允许，返回使其允许的规则。"`
+	g.Meta `path:"/checkEx" tags:"Auth" method:"post" summary:"解释权限来源" dc:"给定sub dom obj act，返回完整的判定过程：对象在域中通过哪些角色继承（例如 UPN → 配额池 → auto_qp_* 规则），所有匹配的 allow 规则，以及覆盖它们的 deny 规则。<br>拒绝时通过 explanation 说明原因。"`
 	Sub    string `json:"sub" v:"required" dc:"对象" example:"sadt@cuhk.edu.cn"`
+	Dom    string `json:"dom" d:"default" dc:"域，不传时为 default 域" example:"default"`
 	Obj    string `json:"obj" v:"required" dc:"资源" example:"platform"`
 	Act    string `json:"act" v:"required" dc:"动作" example:"entry"`
 }
 type CheckAndExplainRes struct {
+	Allow       bool              `json:"allow"`
+	Reason      []string          `json:"reason" dc:"注意只有 allow = true 的时候才会返回 [5]string, 按顺序依次是 sub, dom, obj, act, eft。" example:"[\"alice\",\"default\",\"platform\",\"entry\",\"allow\"]"`
+	Roles       []RoleInheritance `json:"roles" dc:"对象在域中直接或间接继承的所有角色，以及继承路径"`
+	AllowRules  []MatchedRule     `json:"allowRules" dc:"所有匹配的 allow 规则"`
+	DenyRules   []MatchedRule     `json:"denyRules" dc:"所有匹配的 deny 规则。存在 deny 规则时，无论有没有 allow 规则都会拒绝"`
+	Explanation string            `json:"explanation" dc:"判定结果的说明" example:"拒绝：没有匹配的规则"`
+}
+
+// RoleInheritance 对象继承的一个角色
+type RoleInheritance struct {
+	Role string   `json:"role" dc:"角色，例如配额池或 auto_qp_* 规则"`
+	Path []string `json:"path" dc:"从对象到该角色的继承路径，首个元素是对象本身" example:"[\"alice\",\"personal-alice\",\"auto_qp_student\"]"`
+}
+
+// MatchedRule 一条与请求匹配的规则
+type MatchedRule struct {
+	Rule []string `json:"rule" dc:"规则，按顺序依次是 sub, dom, obj, act, eft" example:"[\"auto_qp_student\",\"default\",\"chat/approach/*\",\"entry\",\"allow\"]"`
+	Path []string `json:"path" dc:"对象到规则 sub 的继承路径。属性条件规则为对象本身和属性条件" example:"[\"alice\",\"personal-alice\",\"auto_qp_student\"]"`
+}
+
+// CheckTuple 一次权限检查的请求
+type CheckTuple struct {
+	Sub string `json:"sub" v:"required" dc:"对象" example:"sadt@cuhk.edu.cn"`
+	Dom string `json:"dom" dc:"域，不传时为 default 域" example:"default"`
+	Obj string `json:"obj" v:"required" dc:"资源" example:"platform"`
+	Act string `json:"act" v:"required" dc:"动作" example:"entry"`
+}
+
+// CheckBatchResult 一次权限检查的结果，与请求按顺序一一对应
+type CheckBatchResult struct {
+	CheckTuple
 	Allow  bool     `json:"allow"`
-	Reason []string `json:"reason" dc:"注意只有 allow = true 的时候才会返回 [3]string, 按顺序依次是 sub, obj, act。" example:"[\"alice\",\"platform\",\"entry\"]"`
+	Reason []string `json:"reason,omitempty" dc:"使其允许的规则，只有 explain = true 且 allow = true 时返回"`
+}
+
+// ListObjectsQuery 列出对象可以执行某个动作的所有资源
+type ListObjectsQuery struct {
+	Sub    string `json:"sub" v:"required" dc:"对象" example:"sadt@cuhk.edu.cn"`
+	Dom    string `json:"dom" dc:"域，不传时为 default 域。只列出该域的规则中出现过的资源" example:"default"`
+	Act    string `json:"act" v:"required" dc:"动作" example:"entry"`
+	Prefix string `json:"prefix" dc:"资源前缀，支持 keyMatch 通配符，留空时检查所有资源" example:"chat/approach/*"`
+}
+
+type CheckBatchReq struct {
+	g.Meta      `path:"/checkBatch" tags:"Auth" method:"post" summary:"批量权限检查" dc:"一次检查多组 sub dom obj act，所有检查在同一个读锁下完成，结果与请求按顺序一一对应。<br>传 listObjects 时，列出对象可以执行该动作、且匹配资源前缀的所有资源。候选资源来自现有规则中出现过的具体资源（不含通配符）。<br>requests 和 listObjects 可以同时传。"`
+	Requests    []CheckTuple      `json:"requests" dc:"检查列表，最多 1000 组"`
+	Explain     bool              `json:"explain" dc:"是否返回使其允许的规则"`
+	ListObjects *ListObjectsQuery `json:"listObjects" dc:"列出可访问资源"`
+}
+type CheckBatchRes struct {
+	Results []CheckBatchResult `json:"results" dc:"检查结果，与 requests 按顺序一一对应"`
+	Objects []string           `json:"objects,omitempty" dc:"listObjects 的结果：对象可以执行该动作的资源"`
 }
 
 type GetAllSubjectsReq struct {
-	g.Meta `path:"/admin/subjects/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有Subjects"`
+	g.Meta `path:"/admin/subjects/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有Subjects" dc:"只返回域中规则出现过的值。"`
+	Dom    string `json:"dom" d:"default" dc:"域，不传时为 default 域"`
 }
 type GetAllSubjectsRes struct {
 	Subjects []string `json:"subjects" dc:"Subjects"`
 }
 
 type GetAllObjectsReq struct {
-	g.Meta `path:"/admin/objects/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有Objects"`
+	g.Meta `path:"/admin/objects/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有Objects" dc:"只返回域中规则出现过的值。"`
+	Dom    string `json:"dom" d:"default" dc:"域，不传时为 default 域"`
 }
 type GetAllObjectsRes struct {
 	Objects []string `json:"objects" dc:"Objects"`
 }
 
 type GetAllActionsReq struct {
-	g.Meta `path:"/admin/actions/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有Actions"`
+	g.Meta `path:"/admin/actions/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有Actions" dc:"只返回域中规则出现过的值。"`
+	Dom    string `json:"dom" d:"default" dc:"域，不传时为 default 域"`
 }
 type GetAllActionsRes struct {
 	Actions []string `json:"actions" dc:"Actions"`
 }
 
 type GetAllRolesReq struct {
-	g.Meta `path:"/admin/roles/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有 Roles"`
+	g.Meta `path:"/admin/roles/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有 Roles" dc:"只返回域中规则出现过的值。"`
+	Dom    string `json:"dom" d:"default" dc:"域，不传时为 default 域"`
 }
 type GetAllRolesRes struct {
 	Roles []string `json:"roles" dc:"Roles"`
 }
 
+type GetAllDomainsReq struct {
+	g.Meta `path:"/admin/domains/all" tags:"Auth/Admin/Query" method:"get" summary:"获取所有域" dc:"返回所有规则中出现过的域，总是包含 default 域。"`
+}
+type GetAllDomainsRes struct {
+	Domains []string `json:"domains" dc:"Domains"`
+}
+
 type GetAllQuotaPoolsReq struct {
 	g.Meta `path:"/quotaPools/all" tags:"Auth" method:"get" summary:"获取所属配额池" dc:"动态获取用户属于哪些配额池。"`
 	Upn    string `json:"upn" v:"required" dc:"Upn" example:"sadt@cuhk.edu.cn"`
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// LintIssue 一条规则检查结果
+type LintIssue struct {
+	Kind     string     `json:"kind" dc:"问题类型：duplicate | unreachable | shadowed | roleCycle | emptyRole | unknownSubject"`
+	Severity string     `json:"severity" dc:"严重程度：error | warning | info"`
+	PType    string     `json:"ptype" dc:"问题所在规则的类型：p | g，与具体规则无关时为空"`
+	Rule     []string   `json:"rule" dc:"问题所在的规则"`
+	Related  [][]string `json:"related" dc:"造成问题的其他规则，例如遮蔽 allow 规则的 deny 规则、组成循环的 g 规则"`
+	Message  string     `json:"message" dc:"问题说明"`
+}
+
+type LintPoliciesReq struct {
+	g.Meta   `path:"/admin/lint" tags:"Auth/Admin/Lint" method:"get" summary:"检查规则" dc:"分析当前加载的模型和规则，报告可能的配置问题：<br>duplicate 重复的规则；unreachable 永远不会生效的规则（eft 不合法、有效期已结束、属性条件无法编译）；shadowed 被 deny 规则全部或部分遮蔽的 allow 规则；roleCycle 角色循环继承；emptyRole 自身及上级角色都没有 p 规则的角色；unknownSubject 不是角色、配额池，也不在用户信息中的对象。<br>角色继承只在同一个域内生效，遮蔽、循环和空角色都按域分别分析，返回的规则包含域字段。"`
+	Kind     string `json:"kind" v:"in:duplicate,unreachable,shadowed,roleCycle,emptyRole,unknownSubject" dc:"只返回该类型的问题"`
+	Severity string `json:"severity" v:"in:error,warning,info" dc:"只返回该严重程度的问题"`
+}
+type LintPoliciesRes struct {
+	Matcher       string         `json:"matcher" dc:"分析时使用的 matcher"`
+	PolicyCount   int            `json:"policyCount" dc:"分析的 p 规则条数"`
+	GroupingCount int            `json:"groupingCount" dc:"分析的 g 规则条数"`
+	Counts        map[string]int `json:"counts" dc:"各类型问题的条数，不受筛选条件影响"`
+	Issues        []LintIssue    `json:"issues" dc:"检查结果"`
+}
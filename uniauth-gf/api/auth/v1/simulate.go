@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// AccessChange 修改前后判定结果发生变化的请求
+type AccessChange struct {
+	Sub    string `json:"sub" dc:"对象"`
+	Dom    string `json:"dom" dc:"域"`
+	Obj    string `json:"obj" dc:"资源"`
+	Act    string `json:"act" dc:"动作"`
+	Before bool   `json:"before" dc:"修改前是否允许"`
+	After  bool   `json:"after" dc:"修改后是否允许"`
+}
+
+type SimulatePoliciesReq struct {
+	g.Meta          `path:"/admin/simulate" tags:"Auth/Admin/Simulate" method:"post" summary:"模拟规则修改" dc:"在当前规则的内存副本上应用待新增和删除的 p 规则、g 规则，返回哪些请求的判定结果会发生变化。不写数据库，也不影响当前生效的规则。<br>传 requests 时只判定这些请求；不传时判定受影响角色的所有成员对这些角色规则中的资源和动作的访问，带通配符的资源按模式本身判定，超过 10 万组时需要指定 requests。<br>待修改的规则不含域字段，都在 dom 域中。"`
+	Dom             string       `json:"dom" d:"default" dc:"域，不传时为 default 域。requests 中没有传域的请求也在这个域中判定"`
+	AddPolicies     [][]string   `json:"addPolicies" dc:"待新增的 p 规则，每条为 sub, obj, act, eft" example:"[[\"itso\",\"chat/approach/gpt-4o\",\"entry\",\"allow\"]]"`
+	RemovePolicies  [][]string   `json:"removePolicies" dc:"待删除的 p 规则"`
+	AddGroupings    [][]string   `json:"addGroupings" dc:"待新增的 g 规则，每条为成员, 角色" example:"[[\"sadt@cuhk.edu.cn\",\"itso\"]]"`
+	RemoveGroupings [][]string   `json:"removeGroupings" dc:"待删除的 g 规则"`
+	Requests        []CheckTuple `json:"requests" v:"max-length:10000" dc:"需要判定的请求，最多 10000 组"`
+}
+type SimulatePoliciesRes struct {
+	Checked   int            `json:"checked" dc:"判定的请求组数"`
+	Gained    int            `json:"gained" dc:"修改后变为允许的请求组数"`
+	Lost      int            `json:"lost" dc:"修改后变为拒绝的请求组数"`
+	Changes   []AccessChange `json:"changes" dc:"判定结果发生变化的请求"`
+	Policies  RuleDiff       `json:"policies" dc:"实际发生的 p 规则变化，不含已经存在的新增规则和不存在的删除规则。规则不含域字段"`
+	Groupings RuleDiff       `json:"groupings" dc:"实际发生的 g 规则变化"`
+}
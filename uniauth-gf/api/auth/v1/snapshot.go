@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// SnapshotItem 快照概要，不含规则内容
+type SnapshotItem struct {
+	Id            int64       `json:"id" orm:"id" dc:"快照 ID"`
+	Name          string      `json:"name" orm:"name" dc:"快照名称"`
+	Kind          string      `json:"kind" orm:"kind" dc:"快照类型：manual | auto | rollback"`
+	Operation     string      `json:"operation" orm:"operation" dc:"触发自动快照的业务操作"`
+	Actor         string      `json:"actor" orm:"actor" dc:"操作者"`
+	PolicyCount   int         `json:"policyCount" orm:"policy_count" dc:"p 规则条数"`
+	GroupingCount int         `json:"groupingCount" orm:"grouping_count" dc:"g 规则条数"`
+	CreatedAt     *gtime.Time `json:"createdAt" orm:"created_at" dc:"快照时间"`
+}
+
+// RuleDiff 两组规则之间的差异
+type RuleDiff struct {
+	Added   [][]string `json:"added" dc:"新增的规则"`
+	Removed [][]string `json:"removed" dc:"删除的规则"`
+}
+
+type CreateSnapshotReq struct {
+	g.Meta `path:"/admin/snapshots/create" tags:"Auth/Admin/Snapshot" method:"post" summary:"创建规则快照" dc:"保存当前全部 p 规则和 g 规则，以及这些规则的有效期。批量操作（例如刷新配额池用户组）之前系统也会自动创建快照。"`
+	Name   string `json:"name" v:"max-length:255" dc:"快照名称" example:"调整 itso 权限前"`
+}
+type CreateSnapshotRes struct {
+	SnapshotItem
+}
+
+type GetSnapshotsReq struct {
+	g.Meta   `path:"/admin/snapshots" tags:"Auth/Admin/Snapshot" method:"get" summary:"查询规则快照" dc:"按时间倒序返回快照列表，不含规则内容。"`
+	Kind     string `json:"kind" v:"in:manual,auto,rollback" dc:"快照类型：manual | auto | rollback"`
+	Page     int    `json:"page" v:"min:1" d:"1" dc:"页码，从1开始"`
+	PageSize int    `json:"pageSize" v:"min:1|max:1000" d:"20" dc:"每页条数，最大1000"`
+}
+type GetSnapshotsRes struct {
+	Items      []SnapshotItem `json:"items" dc:"快照列表"`
+	Total      int            `json:"total" dc:"总记录数"`
+	Page       int            `json:"page" dc:"当前页码"`
+	PageSize   int            `json:"pageSize" dc:"每页条数"`
+	TotalPages int            `json:"totalPages" dc:"总页数"`
+}
+
+type DiffSnapshotsReq struct {
+	g.Meta `path:"/admin/snapshots/diff" tags:"Auth/Admin/Snapshot" method:"get" summary:"对比规则快照" dc:"返回从 fromId 快照到 toId 快照的规则变化。不传 toId 时与当前生效的规则对比。"`
+	FromId int64 `json:"fromId" v:"required|min:1" dc:"基准快照 ID"`
+	ToId   int64 `json:"toId" v:"min:0" dc:"目标快照 ID，不传或为 0 时表示当前生效的规则"`
+}
+type DiffSnapshotsRes struct {
+	Policies  RuleDiff `json:"policies" dc:"p 规则的差异"`
+	Groupings RuleDiff `json:"groupings" dc:"g 规则的差异"`
+}
+
+type RollbackSnapshotReq struct {
+	g.Meta `path:"/admin/snapshots/rollback" tags:"Auth/Admin/Snapshot" method:"post" summary:"回滚到规则快照" dc:"把全部 p 规则和 g 规则及其有效期恢复为快照中的状态，并通知所有实例重新加载。<br>回滚在一个数据库事务中完成，失败时规则保持不变。回滚前会自动为当前规则创建一个 rollback 类型的快照，可以用它撤销本次回滚。"`
+	Id     int64 `json:"id" v:"required|min:1" dc:"快照 ID"`
+}
+type RollbackSnapshotRes struct {
+	BackupSnapshotId int64    `json:"backupSnapshotId" dc:"回滚前自动创建的快照 ID"`
+	Policies         RuleDiff `json:"policies" dc:"回滚带来的 p 规则变化"`
+	Groupings        RuleDiff `json:"groupings" dc:"回滚带来的 g 规则变化"`
+}
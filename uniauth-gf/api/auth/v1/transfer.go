@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+type ExportRulesReq struct {
+	g.Meta `path:"/admin/export" tags:"Auth/Admin/Transfer" method:"get" summary:"导出规则" dc:"以文件形式导出当前生效的 p 规则和 g 规则，可以直接用导入接口导入到其他环境。<br>csv 为 Casbin 策略文件格式，开头两行为说明各列的 # 注释，之后每行一条规则，第一列为 p 或 g，p 规则为 sub, dom, obj, act, eft，g 规则为成员, 角色, dom；json 为 {\"policies\": [...], \"groupings\": [...]}。<br>设置了有效期的规则末尾多两列生效时间和失效时间（RFC 3339），为空表示不限。"`
+	Format string `json:"format" v:"in:csv,json" d:"csv" dc:"文件格式：csv | json"`
+	PType  string `json:"ptype" v:"in:p,g" dc:"只导出 p 规则或 g 规则，不传时两者都导出"`
+	Dom    string `json:"dom" dc:"只导出该域的规则，不传时导出所有域。导出的规则包含域字段"`
+	Sub    string `json:"sub" dc:"只导出 sub（p 规则）或成员（g 规则）为该值的规则"`
+	Obj    string `json:"obj" dc:"只导出 obj 为该值的 p 规则，传了时不导出 g 规则"`
+	Role   string `json:"role" dc:"只导出角色为该值的 g 规则，传了时不导出 p 规则"`
+}
+type ExportRulesRes struct{}
+
+type ImportRulesReq struct {
+	g.Meta `path:"/admin/import" tags:"Auth/Admin/Transfer" method:"post" mime:"multipart/form-data" summary:"导入规则" dc:"导入导出接口生成的规则文件。文件先整体校验，有任何错误都不会导入。<br>merge 模式只添加当前没有的规则；replace 模式把全部规则替换为文件中的规则，文件中没有的规则会被删除。<br>规则末尾可以有生效时间和失效时间两列，与导出的文件一致。merge 模式只给新增的规则设置有效期；replace 模式按文件替换全部有效期，没有这两列的规则永久有效。<br>dryRun 默认开启，只返回将要新增和删除的规则；确认后传 dryRun=false 才会写入。写入在一个数据库事务中完成，写入前会自动创建快照，可以用快照回滚撤销本次导入。"`
+	File   *ghttp.UploadFile `json:"file" v:"required" type:"file" dc:"规则文件"`
+	Format string            `json:"format" v:"in:csv,json" dc:"文件格式：csv | json，不传时按文件扩展名判断"`
+	Mode   string            `json:"mode" v:"in:merge,replace" d:"merge" dc:"导入模式：merge | replace"`
+	DryRun bool              `json:"dryRun" d:"true" dc:"只返回差异，不写入"`
+}
+type ImportRulesRes struct {
+	Applied          bool     `json:"applied" dc:"是否已经写入"`
+	BackupSnapshotId int64    `json:"backupSnapshotId" dc:"写入前自动创建的快照 ID，没有写入时为 0"`
+	PolicyCount      int      `json:"policyCount" dc:"文件中的 p 规则条数"`
+	GroupingCount    int      `json:"groupingCount" dc:"文件中的 g 规则条数"`
+	Policies         RuleDiff `json:"policies" dc:"p 规则的变化"`
+	Groupings        RuleDiff `json:"groupings" dc:"g 规则的变化"`
+}
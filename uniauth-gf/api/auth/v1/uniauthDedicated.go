@@ -2,13 +2,49 @@ package v1
 
 import (
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 type UniauthLoginReq struct {
-	g.Meta   `path:"/uniauth/login" tags:"Auth/UniAuth" method:"post" summary:"UniAuth账号密码校验" dc:"UniAuth账号密码校验"`
+	g.Meta   `path:"/uniauth/login" tags:"Auth/UniAuth" method:"post" summary:"UniAuth账号密码校验" dc:"管理员账号密码校验。校验通过时创建会话并返回令牌，调用管理接口时通过 Authorization: Bearer <token> 请求头携带。<br>账号或密码错误时 ok 为 false。"`
 	Account  string `json:"account" v:"required" example:"admin"`
 	Password string `json:"password" v:"required" example:"123456"`
 }
 type UniauthLoginRes struct {
+	Ok        bool        `json:"ok"`
+	Token     string      `json:"token,omitempty" dc:"会话令牌，只返回这一次" example:"uas_3f9c..."`
+	ExpiresAt *gtime.Time `json:"expiresAt,omitempty" dc:"会话过期时间"`
+}
+
+type UniauthLogoutReq struct {
+	g.Meta `path:"/uniauth/logout" tags:"Auth/UniAuth" method:"post" summary:"注销会话" dc:"注销 Authorization 请求头中的会话令牌。令牌无效时不会报错。"`
+}
+type UniauthLogoutRes struct {
 	Ok bool `json:"ok"`
-}
\ No newline at end of file
+}
+
+type UniauthOidcLoginReq struct {
+	g.Meta   `path:"/uniauth/oidc/login" tags:"Auth/UniAuth" method:"get" summary:"单点登录" dc:"跳转到身份提供方登录（OIDC 授权码流程）。登录完成后跳转回 redirect，会话令牌放在 URL 片段中：<redirect>#token=<token>&expiresAt=<过期时间>。<br>单点登录的管理员在 Casbin 中的 subject 为 admin:<UPN>，UPN 必须在用户信息表中。"`
+	Redirect string `json:"redirect" dc:"登录完成后跳转的管理后台地址，协议和主机必须与 oidc.allowedRedirects 中的某个地址完全相同，路径必须是该地址的路径或在其之下。不传时使用 oidc.postLoginUrl"`
+}
+type UniauthOidcLoginRes struct{}
+
+type UniauthOidcCallbackReq struct {
+	g.Meta           `path:"/uniauth/oidc/callback" tags:"Auth/UniAuth" method:"get" summary:"单点登录回调" dc:"身份提供方登录完成后的回调地址，需要配置为 oidc.redirectUrl，不应直接调用。"`
+	Code             string `json:"code" dc:"授权码"`
+	State            string `json:"state" v:"required" dc:"发起登录时生成的 state"`
+	Error            string `json:"error" dc:"身份提供方返回的错误"`
+	ErrorDescription string `json:"error_description" dc:"身份提供方返回的错误说明"`
+}
+type UniauthOidcCallbackRes struct{}
+
+type UniauthMeReq struct {
+	g.Meta `path:"/uniauth/me" tags:"Auth/UniAuth" method:"get" summary:"当前管理员" dc:"返回 Authorization 请求头中的会话对应的管理员。"`
+}
+type UniauthMeRes struct {
+	Username    string      `json:"username" dc:"用户名，单点登录时为 UPN"`
+	DisplayName string      `json:"displayName" dc:"显示名称"`
+	Provider    string      `json:"provider" dc:"登录方式：password 账号密码 | oidc 单点登录"`
+	Subject     string      `json:"subject" dc:"在 Casbin 中的 subject" example:"admin:alice"`
+	ExpiresAt   *gtime.Time `json:"expiresAt" dc:"会话过期时间"`
+}
@@ -18,6 +18,9 @@ type IBillingV1 interface {
 	CheckBalance(ctx context.Context, req *v1.CheckBalanceReq) (res *v1.CheckBalanceRes, err error)
 	CheckTokensUsage(ctx context.Context, req *v1.CheckTokensUsageReq) (res *v1.CheckTokensUsageRes, err error)
 	GetBillingOptions(ctx context.Context, req *v1.GetBillingOptionsReq) (res *v1.GetBillingOptionsRes, err error)
+	ReserveQuota(ctx context.Context, req *v1.ReserveQuotaReq) (res *v1.ReserveQuotaRes, err error)
+	CommitReservation(ctx context.Context, req *v1.CommitReservationReq) (res *v1.CommitReservationRes, err error)
+	ReleaseReservation(ctx context.Context, req *v1.ReleaseReservationReq) (res *v1.ReleaseReservationRes, err error)
 	NDaysProductUsageChart(ctx context.Context, req *v1.NDaysProductUsageChartReq) (res *v1.NDaysProductUsageChartRes, err error)
 	NDaysProductUsageGroup(ctx context.Context, req *v1.NDaysProductUsageGroupReq) (res *v1.NDaysProductUsageGroupRes, err error)
 }
@@ -6,6 +6,12 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// CostItem 一笔指定币种的费用
+type CostItem struct {
+	Currency string          `json:"currency" v:"required|length:3,3" dc:"ISO 4217 货币代码" example:"EUR"`
+	Amount   decimal.Decimal `json:"amount" v:"required" dc:"金额" example:"1.5"`
+}
+
 type BillingRecordReq struct {
 	// g.Meta `path:"/record" tags:"Billing" method:"post" summary:"计费接口" dc:"上传计费请求，完成配额池的扣费。"`
 	g.Meta `path:"/record" tags:"Billing" method:"post" summary:"计费接口" dc:"上传计费请求，完成配额池的扣费。" resEg:"resource/interface/billing/billing_record_req.json"`
@@ -17,16 +23,23 @@ type BillingRecordReq struct {
 
 	CNYCost decimal.Decimal `json:"cny_cost"`
 	USDCost decimal.Decimal `json:"usd_cost"`
+	Costs   []CostItem      `json:"costs" dc:"任意币种的费用列表，按当天汇率换算为本位货币后与 cny_cost、usd_cost 累加"`
 
 	Remark *gjson.Json `json:"detail"`
+
+	RequestId string `json:"request_id" dc:"幂等键。由调用方生成并在重试时保持不变，同一个幂等键只会计费一次。重放时直接返回首次计费的结果；upn、service、product 或 source 与首次请求不同时返回错误。" example:"chat-7b0c1f0e-5d1a-4c57-9a41-1b1c2f3e4d5f"`
 }
 type BillingRecordRes struct {
-	Ok bool `json:"ok" v:"required"`
+	Ok       bool            `json:"ok" v:"required"`
+	RecordId int64           `json:"recordId" dc:"计费记录 ID"`
+	Cost     decimal.Decimal `json:"cost" dc:"实际扣费金额（折扣后）"`
+	Replayed bool            `json:"replayed" dc:"是否为重放请求。为 true 时本次请求没有重复计费，返回的是首次计费的结果。"`
 }
 
 type CheckBalanceReq struct {
-	g.Meta    `path:"/check" tags:"Billing" method:"post" summary:"检查余额" dc:"刷新、检查配额池的余额。"`
+	g.Meta    `path:"/check" tags:"Billing" method:"post" summary:"检查余额" dc:"刷新、检查配额池的余额。传了 upn 时，还会检查该用户在配额池内的个人限额。"`
 	QuotaPool string `json:"quotaPool" v:"required" example:"itso-deep-research-vip"`
+	Upn       string `json:"upn" dc:"UPN，可选" example:"122020255@link.cuhk.edu.cn"`
 }
 type CheckBalanceRes struct {
 	Ok bool `json:"ok" v:"required" example:"true"`
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/shopspring/decimal"
+)
+
+// reservation：长时间流式对话的两阶段扣费。开始前预留（冻结）预估额度，结束后提交实际费用或释放预留。
+
+type ReserveQuotaReq struct {
+	g.Meta  `path:"/reservation/reserve" tags:"Billing/Reservation" method:"post" summary:"预留额度" dc:"在配额池上冻结一笔预估额度，冻结的额度在有效期内不能被其他请求使用。<br>可用余额 = 剩余配额 + 加油包 - 未过期预留的冻结总额，可用余额不足时返回错误。"`
+	Upn     string          `json:"upn" v:"required" example:"122020255@link.cuhk.edu.cn"`
+	Service string          `json:"service" v:"required" example:"chat"`
+	Product string          `json:"product" v:"required" example:"deep-research"`
+	Source  string          `json:"source" v:"required" dc:"配额池" example:"itso-deep-research-vip"`
+	Amount  decimal.Decimal `json:"amount" v:"required" dc:"预留金额（折扣后的本位货币金额）" example:"10"`
+	Ttl     int             `json:"ttl" v:"min:1|max:86400" d:"600" dc:"有效期（秒）。超过有效期仍未提交的预留会自动失效。"`
+}
+type ReserveQuotaRes struct {
+	Ok            bool        `json:"ok"`
+	ReservationId string      `json:"reservationId" dc:"预留 ID，提交或释放时使用"`
+	ExpiresAt     *gtime.Time `json:"expiresAt" dc:"过期时间"`
+}
+
+type CommitReservationReq struct {
+	g.Meta        `path:"/reservation/commit" tags:"Billing/Reservation" method:"post" summary:"提交预留" dc:"按实际费用完成计费，并释放预留中未使用的部分。扣费沿用计费接口的逻辑。<br>重复提交同一个预留不会重复扣费，直接返回首次提交的结果。已经过期的预留仍然可以提交。"`
+	ReservationId string          `json:"reservationId" v:"required"`
+	CNYCost       decimal.Decimal `json:"cny_cost"`
+	USDCost       decimal.Decimal `json:"usd_cost"`
+	Costs         []CostItem      `json:"costs" dc:"任意币种的费用列表，同计费接口"`
+	Remark        *gjson.Json     `json:"detail"`
+}
+type CommitReservationRes struct {
+	Ok       bool            `json:"ok"`
+	RecordId int64           `json:"recordId" dc:"计费记录 ID"`
+	Cost     decimal.Decimal `json:"cost" dc:"实际扣费金额（折扣后）"`
+}
+
+type ReleaseReservationReq struct {
+	g.Meta        `path:"/reservation/release" tags:"Billing/Reservation" method:"post" summary:"释放预留" dc:"放弃一笔预留，解冻全部额度，不扣费。重复释放不会报错。"`
+	ReservationId string `json:"reservationId" v:"required"`
+}
+type ReleaseReservationRes struct {
+	Ok bool `json:"ok"`
+}
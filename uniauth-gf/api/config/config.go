@@ -21,6 +21,9 @@ type IConfigV1 interface {
 	AddModelConfig(ctx context.Context, req *v1.AddModelConfigReq) (res *v1.AddModelConfigRes, err error)
 	EditModelConfig(ctx context.Context, req *v1.EditModelConfigReq) (res *v1.EditModelConfigRes, err error)
 	DeleteModelConfig(ctx context.Context, req *v1.DeleteModelConfigReq) (res *v1.DeleteModelConfigRes, err error)
+	GetExchangeRates(ctx context.Context, req *v1.GetExchangeRatesReq) (res *v1.GetExchangeRatesRes, err error)
+	UploadExchangeRates(ctx context.Context, req *v1.UploadExchangeRatesReq) (res *v1.UploadExchangeRatesRes, err error)
+	ConvertCurrency(ctx context.Context, req *v1.ConvertCurrencyReq) (res *v1.ConvertCurrencyRes, err error)
 	GetI18nConfig(ctx context.Context, req *v1.GetI18nConfigReq) (res *v1.GetI18nConfigRes, err error)
 	GetAllApps(ctx context.Context, req *v1.GetAllAppsReq) (res *v1.GetAllAppsRes, err error)
 	AddI18nItem(ctx context.Context, req *v1.AddI18nItemReq) (res *v1.AddI18nItemRes, err error)
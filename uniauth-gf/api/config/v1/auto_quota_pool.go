@@ -10,18 +10,23 @@ import (
 )
 
 type AutoQuotaPoolItem struct {
-	Id                 int64           `json:"id"                 orm:"id"                   description:"自增主键"`         // 自增主键
-	RuleName           string          `json:"ruleName"           orm:"rule_name"            description:"规则名称，唯一"`      // 规则名称，唯一
-	Description        string          `json:"description"        orm:"description"          description:"规则说明"`         // 规则说明
-	CronCycle          string          `json:"cronCycle"          orm:"cron_cycle"           description:"刷新周期"`         // 刷新周期
-	RegularQuota       decimal.Decimal `json:"regularQuota"       orm:"regular_quota"        description:"定期配额"`         // 定期配额
-	Enabled            bool            `json:"enabled"            orm:"enabled"              description:"是否启用该配额池"`     // 是否启用该配额池
-	FilterGroup        *gjson.Json     `json:"filterGroup"        orm:"filter_group"         description:"过滤条件组"`        // 过滤条件组
-	Priority           int             `json:"priority"           orm:"priority"             description:"优先级，数值越小优先匹配"` // 优先级，数值越小优先匹配
-	LastEvaluatedAt    *gtime.Time     `json:"lastEvaluatedAt"    orm:"last_evaluated_at"    description:"该规则上次评估时间"`    // 该规则上次评估时间
-	CreatedAt          *gtime.Time     `json:"createdAt"          orm:"created_at"           description:"创建时间"`         // 创建时间
-	UpdatedAt          *gtime.Time     `json:"updatedAt"          orm:"updated_at"           description:"更新时间"`         // 更新时间
-	DefaultCasbinRules *gjson.Json     `json:"defaultCasbinRules" orm:"default_casbin_rules" description:"默认Casbin规则"`   // 默认Casbin规则
+	Id                 int64           `json:"id"                 orm:"id"                    description:"自增主键"`                       // 自增主键
+	RuleName           string          `json:"ruleName"           orm:"rule_name"             description:"规则名称，唯一"`                    // 规则名称，唯一
+	Description        string          `json:"description"        orm:"description"           description:"规则说明"`                       // 规则说明
+	CronCycle          string          `json:"cronCycle"          orm:"cron_cycle"            description:"刷新周期"`                       // 刷新周期
+	RegularQuota       decimal.Decimal `json:"regularQuota"       orm:"regular_quota"         description:"定期配额"`                       // 定期配额
+	Enabled            bool            `json:"enabled"            orm:"enabled"               description:"是否启用该配额池"`                   // 是否启用该配额池
+	FilterGroup        *gjson.Json     `json:"filterGroup"        orm:"filter_group"          description:"过滤条件组"`                      // 过滤条件组
+	Priority           int             `json:"priority"           orm:"priority"              description:"优先级，数值越小优先匹配"`               // 优先级，数值越小优先匹配
+	UserQuotaLimit     decimal.Decimal `json:"userQuotaLimit"     orm:"user_quota_limit"      description:"个人配额池中用户每周期的消费上限，小于 0 时不限制"` // 个人配额池中用户每周期的消费上限，小于 0 时不限制
+	UserLimitCronCycle string          `json:"userLimitCronCycle" orm:"user_limit_cron_cycle" description:"用户消费上限的统计周期，为空时沿用刷新周期"`      // 用户消费上限的统计周期，为空时沿用刷新周期
+	RolloverPolicy     string          `json:"rolloverPolicy"     orm:"rollover_policy"       description:"个人配额池的余额结转策略"`               // 个人配额池的余额结转策略
+	RolloverCapPercent decimal.Decimal `json:"rolloverCapPercent" orm:"rollover_cap_percent"  description:"结余结转上限，定期配额的百分比，小于 0 时不限制"`  // 结余结转上限，定期配额的百分比，小于 0 时不限制
+	RolloverCapAmount  decimal.Decimal `json:"rolloverCapAmount"  orm:"rollover_cap_amount"   description:"结余结转上限，绝对值，小于 0 时不限制"`       // 结余结转上限，绝对值，小于 0 时不限制
+	LastEvaluatedAt    *gtime.Time     `json:"lastEvaluatedAt"    orm:"last_evaluated_at"     description:"该规则上次评估时间"`                  // 该规则上次评估时间
+	CreatedAt          *gtime.Time     `json:"createdAt"          orm:"created_at"            description:"创建时间"`                       // 创建时间
+	UpdatedAt          *gtime.Time     `json:"updatedAt"          orm:"updated_at"            description:"更新时间"`                       // 更新时间
+	DefaultCasbinRules *gjson.Json     `json:"defaultCasbinRules" orm:"default_casbin_rules"  description:"默认Casbin规则"`                 // 默认Casbin规则
 }
 
 type DefaultCasbinRule struct {
@@ -55,6 +60,15 @@ type EditAutoQuotaPoolConfigReq struct {
 	Description string `json:"description" dc:"规则说明" example:"为学生每日分配基础额度"`
 	// 优先级，数值越小优先匹配
 	Priority int `json:"priority" dc:"优先级，数值越小优先匹配" example:"10"`
+	// 每周期消费上限，与刷新周期可以不同，例如每月配额内再限制每日消费
+	UserQuotaLimit *decimal.Decimal `json:"userQuotaLimit" dc:"个人配额池中用户每周期的消费上限，小于 0 表示不限制，不传则不修改" example:"20"`
+	// 消费上限的统计周期（标准 Cron 表达式），为空时沿用刷新周期
+	UserLimitCronCycle string `json:"userLimitCronCycle" dc:"消费上限的统计周期，Cron 表达式，为空时沿用刷新周期" example:"0 0 * * *"`
+	// 刷新时对上一周期剩余配额的处理
+	RolloverPolicy string `json:"rolloverPolicy" v:"in:discard,carry_over,carry_debt" d:"discard" dc:"余额结转策略：discard 清零 | carry_over 结余结转（欠费免除） | carry_debt 欠费结转（结余清零）" example:"carry_over"`
+	// 结余结转上限，同时设置时取较小者
+	RolloverCapPercent *decimal.Decimal `json:"rolloverCapPercent" dc:"结余结转上限，定期配额的百分比，小于 0 表示不限制，不传则不修改" example:"50"`
+	RolloverCapAmount  *decimal.Decimal `json:"rolloverCapAmount" dc:"结余结转上限，绝对值，小于 0 表示不限制，不传则不修改" example:"500"`
 }
 type EditAutoQuotaPoolConfigRes struct {
 	OK bool `json:"ok" dc:"是否成功"`
@@ -87,6 +101,15 @@ type AddAutoQuotaPoolConfigReq struct {
 	Description string `json:"description" dc:"规则说明" example:"为学生每日分配基础额度"`
 	// 优先级，数值越小优先匹配
 	Priority int `json:"priority" dc:"优先级，数值越小优先匹配" example:"10"`
+	// 每周期消费上限，与刷新周期可以不同，例如每月配额内再限制每日消费
+	UserQuotaLimit *decimal.Decimal `json:"userQuotaLimit" dc:"个人配额池中用户每周期的消费上限，小于 0 或不传表示不限制" example:"20"`
+	// 消费上限的统计周期（标准 Cron 表达式），为空时沿用刷新周期
+	UserLimitCronCycle string `json:"userLimitCronCycle" dc:"消费上限的统计周期，Cron 表达式，为空时沿用刷新周期" example:"0 0 * * *"`
+	// 刷新时对上一周期剩余配额的处理
+	RolloverPolicy string `json:"rolloverPolicy" v:"in:discard,carry_over,carry_debt" d:"discard" dc:"余额结转策略：discard 清零 | carry_over 结余结转（欠费免除） | carry_debt 欠费结转（结余清零）" example:"carry_over"`
+	// 结余结转上限，同时设置时取较小者
+	RolloverCapPercent *decimal.Decimal `json:"rolloverCapPercent" dc:"结余结转上限，定期配额的百分比，小于 0 或不传表示不限制" example:"50"`
+	RolloverCapAmount  *decimal.Decimal `json:"rolloverCapAmount" dc:"结余结转上限，绝对值，小于 0 或不传表示不限制" example:"500"`
 }
 type AddAutoQuotaPoolConfigRes struct {
 	OK bool `json:"ok" dc:"是否成功"`
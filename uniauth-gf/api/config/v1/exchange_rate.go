@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/model/entity"
+)
+
+type ExchangeRateItem struct {
+	Date *gtime.Time     `json:"date" v:"required" dc:"汇率日期" example:"2025-10-01"`
+	F    string          `json:"f" v:"required|length:3,3" dc:"本位货币" example:"USD"`
+	T    string          `json:"t" v:"required|length:3,3" dc:"标的货币" example:"CNY"`
+	Rate decimal.Decimal `json:"rate" v:"required" dc:"1 本位货币 = rate 标的货币" example:"7.12"`
+}
+
+type GetExchangeRatesReq struct {
+	g.Meta    `path:"/exchangeRate" tags:"Config/ExchangeRate" method:"get" summary:"查询汇率" dc:"按日期倒序返回汇率记录。"`
+	F         string      `json:"f" dc:"本位货币，不传则返回全部" example:"USD"`
+	T         string      `json:"t" dc:"标的货币，不传则返回全部" example:"CNY"`
+	StartDate *gtime.Time `json:"startDate" dc:"开始日期（含）" example:"2025-10-01"`
+	EndDate   *gtime.Time `json:"endDate" dc:"结束日期（含）" example:"2025-10-31"`
+	Limit     int         `json:"limit" v:"min:1|max:1000" d:"100" dc:"最多返回条数"`
+}
+type GetExchangeRatesRes struct {
+	Items []entity.ConfigExchangeRate `json:"items" dc:"汇率列表"`
+}
+
+type UploadExchangeRatesReq struct {
+	g.Meta `path:"/exchangeRate" tags:"Config/ExchangeRate" method:"post" summary:"上传汇率" dc:"批量上传或覆盖汇率。同一日期、同一货币对已有的汇率会被覆盖，汇率 API 之后也不会再覆盖手动上传的汇率。"`
+	Rates  []ExchangeRateItem `json:"rates" v:"required|min-length:1" dc:"汇率列表"`
+}
+type UploadExchangeRatesRes struct {
+	OK    bool `json:"ok" dc:"是否成功"`
+	Count int  `json:"count" dc:"写入的汇率条数"`
+}
+
+type ConvertCurrencyReq struct {
+	g.Meta `path:"/exchangeRate/convert" tags:"Config/ExchangeRate" method:"get" summary:"换算金额" dc:"按指定日期的汇率换算金额，便于核对计费。指定日期没有汇率时使用最近一个更早日期的汇率。"`
+	Amount decimal.Decimal `json:"amount" v:"required" dc:"金额" example:"10"`
+	From   string          `json:"from" v:"required|length:3,3" dc:"原币种" example:"USD"`
+	To     string          `json:"to" dc:"目标币种，不传则为本位货币" example:"CNY"`
+	Date   *gtime.Time     `json:"date" dc:"日期，不传则为今天" example:"2025-10-01"`
+}
+type ConvertCurrencyRes struct {
+	Amount   decimal.Decimal `json:"amount" dc:"换算后的金额"`
+	To       string          `json:"to" dc:"目标币种"`
+	Rate     decimal.Decimal `json:"rate" dc:"使用的汇率"`
+	RateDate *gtime.Time     `json:"rateDate" dc:"汇率实际所属的日期"`
+}
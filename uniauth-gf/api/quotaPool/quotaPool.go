@@ -13,6 +13,16 @@ import (
 type IQuotaPoolV1 interface {
 	ResetBalance(ctx context.Context, req *v1.ResetBalanceReq) (res *v1.ResetBalanceRes, err error)
 	BatchModifyQuotaPool(ctx context.Context, req *v1.BatchModifyQuotaPoolReq) (res *v1.BatchModifyQuotaPoolRes, err error)
+	AddAlertRule(ctx context.Context, req *v1.AddAlertRuleReq) (res *v1.AddAlertRuleRes, err error)
+	EditAlertRule(ctx context.Context, req *v1.EditAlertRuleReq) (res *v1.EditAlertRuleRes, err error)
+	DeleteAlertRule(ctx context.Context, req *v1.DeleteAlertRuleReq) (res *v1.DeleteAlertRuleRes, err error)
+	GetAlertRules(ctx context.Context, req *v1.GetAlertRulesReq) (res *v1.GetAlertRulesRes, err error)
+	GetAlertDeliveries(ctx context.Context, req *v1.GetAlertDeliveriesReq) (res *v1.GetAlertDeliveriesRes, err error)
+	TestAlertRule(ctx context.Context, req *v1.TestAlertRuleReq) (res *v1.TestAlertRuleRes, err error)
+	TopUpExtraQuota(ctx context.Context, req *v1.TopUpExtraQuotaReq) (res *v1.TopUpExtraQuotaRes, err error)
+	TransferExtraQuota(ctx context.Context, req *v1.TransferExtraQuotaReq) (res *v1.TransferExtraQuotaRes, err error)
+	GetExtraGrants(ctx context.Context, req *v1.GetExtraGrantsReq) (res *v1.GetExtraGrantsRes, err error)
+	GetQuotaPoolLedger(ctx context.Context, req *v1.GetQuotaPoolLedgerReq) (res *v1.GetQuotaPoolLedgerRes, err error)
 	GetQuotaPool(ctx context.Context, req *v1.GetQuotaPoolReq) (res *v1.GetQuotaPoolRes, err error)
 	FilterQuotaPool(ctx context.Context, req *v1.FilterQuotaPoolReq) (res *v1.FilterQuotaPoolRes, err error)
 	NewQuotaPool(ctx context.Context, req *v1.NewQuotaPoolReq) (res *v1.NewQuotaPoolRes, err error)
@@ -20,4 +30,5 @@ type IQuotaPoolV1 interface {
 	DeleteQuotaPool(ctx context.Context, req *v1.DeleteQuotaPoolReq) (res *v1.DeleteQuotaPoolRes, err error)
 	EnsurePersonalQuotaPool(ctx context.Context, req *v1.EnsurePersonalQuotaPoolReq) (res *v1.EnsurePersonalQuotaPoolRes, err error)
 	RefreshUsersOfQuotaPool(ctx context.Context, req *v1.RefreshUsersOfQuotaPoolReq) (res *v1.RefreshUsersOfQuotaPoolRes, err error)
+	GetUserAllowance(ctx context.Context, req *v1.GetUserAllowanceReq) (res *v1.GetUserAllowanceRes, err error)
 }
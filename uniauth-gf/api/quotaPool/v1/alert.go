@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/model/entity"
+)
+
+// AlertRule 告警规则，不包含签名密钥
+type AlertRule struct {
+	Id              int64           `json:"id" dc:"规则 ID"`
+	QuotaPoolName   string          `json:"quotaPoolName" dc:"配额池名称"`
+	ThresholdType   string          `json:"thresholdType" dc:"阈值类型：absolute | percent"`
+	Threshold       decimal.Decimal `json:"threshold" dc:"阈值"`
+	WebhookUrl      string          `json:"webhookUrl" dc:"Webhook 地址"`
+	Enabled         bool            `json:"enabled" dc:"是否启用"`
+	Triggered       bool            `json:"triggered" dc:"当前是否处于已触发状态"`
+	LastTriggeredAt *gtime.Time     `json:"lastTriggeredAt" dc:"上次触发时间"`
+	CreatedAt       *gtime.Time     `json:"createdAt" dc:"创建时间"`
+	UpdatedAt       *gtime.Time     `json:"updatedAt" dc:"更新时间"`
+}
+
+type AddAlertRuleReq struct {
+	g.Meta        `path:"/alertRule" tags:"QuotaPool/Alert" method:"post" summary:"新建余额告警规则" dc:"配额池可用余额（剩余配额 + 加油包）跌破阈值时，向 Webhook 推送一次签名告警，余额回到阈值以上后重新生效。阈值设为 0 即为透支告警。"`
+	QuotaPoolName string          `json:"quotaPoolName" v:"required" dc:"配额池名称" example:"itso-deep-research-vip"`
+	ThresholdType string          `json:"thresholdType" v:"required|in:absolute,percent" dc:"阈值类型：absolute 绝对值 | percent 定期配额的百分比" example:"percent"`
+	Threshold     decimal.Decimal `json:"threshold" v:"required" dc:"阈值" example:"10"`
+	WebhookUrl    string          `json:"webhookUrl" v:"required|url" dc:"Webhook 地址" example:"https://example.com/hooks/uniauth"`
+	Secret        string          `json:"secret" dc:"签名密钥，不传则自动生成"`
+	Enabled       bool            `json:"enabled" d:"true" dc:"是否启用"`
+}
+type AddAlertRuleRes struct {
+	Id     int64  `json:"id" dc:"规则 ID"`
+	Secret string `json:"secret" dc:"签名密钥，只在创建时返回"`
+}
+
+type EditAlertRuleReq struct {
+	g.Meta        `path:"/alertRule" tags:"QuotaPool/Alert" method:"put" summary:"编辑余额告警规则" dc:"除了 id 字段必传之外，其他字段可以不传。不传的字段不会更新。修改阈值后规则会重新生效。"`
+	Id            int64            `json:"id" v:"required" dc:"规则 ID"`
+	ThresholdType *string          `json:"thresholdType" v:"in:absolute,percent" dc:"阈值类型：absolute | percent"`
+	Threshold     *decimal.Decimal `json:"threshold" dc:"阈值"`
+	WebhookUrl    *string          `json:"webhookUrl" v:"url" dc:"Webhook 地址"`
+	Secret        *string          `json:"secret" dc:"签名密钥"`
+	Enabled       *bool            `json:"enabled" dc:"是否启用"`
+}
+type EditAlertRuleRes struct {
+	OK bool `json:"ok" dc:"是否成功"`
+}
+
+type DeleteAlertRuleReq struct {
+	g.Meta `path:"/alertRule" tags:"QuotaPool/Alert" method:"delete" summary:"删除余额告警规则" dc:"删除规则后，尚未投递的告警不会再重试。"`
+	Id     int64 `json:"id" v:"required" dc:"规则 ID"`
+}
+type DeleteAlertRuleRes struct {
+	OK bool `json:"ok" dc:"是否成功"`
+}
+
+type GetAlertRulesReq struct {
+	g.Meta        `path:"/alertRules" tags:"QuotaPool/Alert" method:"get" summary:"查询余额告警规则"`
+	QuotaPoolName string `json:"quotaPoolName" dc:"配额池名称，不传则返回全部"`
+}
+type GetAlertRulesRes struct {
+	Items []AlertRule `json:"items" dc:"告警规则列表"`
+}
+
+type GetAlertDeliveriesReq struct {
+	g.Meta        `path:"/alertDeliveries" tags:"QuotaPool/Alert" method:"get" summary:"查询告警投递记录" dc:"按时间倒序返回告警的 Webhook 投递记录。"`
+	QuotaPoolName string `json:"quotaPoolName" dc:"配额池名称，不传则返回全部"`
+	RuleId        int64  `json:"ruleId" dc:"规则 ID，不传则返回全部"`
+	Status        string `json:"status" v:"in:pending,delivered,failed" dc:"投递状态，不传则返回全部"`
+	Page          int    `json:"page" v:"min:1" d:"1" dc:"页码，从1开始"`
+	PageSize      int    `json:"pageSize" v:"min:1|max:1000" d:"20" dc:"每页条数，最大1000"`
+}
+type GetAlertDeliveriesRes struct {
+	Items      []entity.QuotapoolAlertDelivery `json:"items" dc:"投递记录列表"`
+	Total      int                             `json:"total" dc:"总记录数"`
+	Page       int                             `json:"page" dc:"当前页码"`
+	PageSize   int                             `json:"pageSize" dc:"每页条数"`
+	TotalPages int                             `json:"totalPages" dc:"总页数"`
+}
+
+type TestAlertRuleReq struct {
+	g.Meta `path:"/alertRule/test" tags:"QuotaPool/Alert" method:"post" summary:"测试余额告警规则" dc:"立即向规则的 Webhook 同步发送一条签名的测试事件，不写投递记录，也不影响规则的触发状态。"`
+	Id     int64 `json:"id" v:"required" dc:"规则 ID"`
+}
+type TestAlertRuleRes struct {
+	OK             bool   `json:"ok" dc:"Webhook 是否返回 2xx"`
+	EventId        string `json:"eventId" dc:"测试事件 ID"`
+	ResponseStatus int    `json:"responseStatus" dc:"HTTP 响应状态码，请求失败时为 0"`
+	Error          string `json:"error" dc:"错误信息"`
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/model/entity"
+)
+
+type TopUpExtraQuotaReq struct {
+	g.Meta        `path:"/admin/topUp" tags:"QuotaPool/Admin" method:"post" summary:"充值加油包" dc:"给配额池充值一笔加油包，可以指定过期时间。扣费时优先扣除最早过期的加油包。"`
+	QuotaPoolName string          `json:"quotaPoolName" v:"required" dc:"配额池名称" example:"itso-deep-research-vip"`
+	Amount        decimal.Decimal `json:"amount" v:"required" dc:"加油包额度" example:"100"`
+	ExpiresAt     *gtime.Time     `json:"expiresAt" dc:"过期时间，不传则永不过期" example:"2025-12-31 23:59:59"`
+	Remark        *gjson.Json     `json:"remark" dc:"备注信息"`
+}
+type TopUpExtraQuotaRes struct {
+	OK      bool  `json:"ok" dc:"是否成功"`
+	GrantId int64 `json:"grantId" dc:"加油包 ID"`
+}
+
+type TransferExtraQuotaReq struct {
+	g.Meta `path:"/admin/transfer" tags:"QuotaPool/Admin" method:"post" summary:"转移加油包" dc:"在两个配额池之间原子地转移加油包额度。转出的额度按过期时间从早到晚扣减，转入后保留原来的过期时间。"`
+	From   string          `json:"from" v:"required" dc:"转出的配额池" example:"itso-deep-research-vip"`
+	To     string          `json:"to" v:"required" dc:"转入的配额池" example:"itso-deep-research-vip-2"`
+	Amount decimal.Decimal `json:"amount" v:"required" dc:"转移额度" example:"50"`
+	Remark *gjson.Json     `json:"remark" dc:"备注信息"`
+}
+type TransferExtraQuotaRes struct {
+	OK bool `json:"ok" dc:"是否成功"`
+}
+
+type GetExtraGrantsReq struct {
+	g.Meta          `path:"/extraGrants" tags:"QuotaPool" method:"get" summary:"查询配额池的加油包" dc:"按过期时间从早到晚返回配额池的加油包，即扣费时的扣减顺序。"`
+	QuotaPoolName   string `json:"quotaPoolName" v:"required" dc:"配额池名称" example:"itso-deep-research-vip"`
+	IncludeInactive bool   `json:"includeInactive" d:"false" dc:"是否同时返回已用完和已过期的加油包"`
+}
+type GetExtraGrantsRes struct {
+	Items []entity.QuotapoolExtraGrant `json:"items" dc:"加油包列表"`
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/model/entity"
+)
+
+type GetQuotaPoolLedgerReq struct {
+	g.Meta        `path:"/ledger" tags:"QuotaPool" method:"get" summary:"查询配额池账本" dc:"按时间倒序返回配额池的余额变动流水，支持按变动原因和时间范围过滤。"`
+	QuotaPoolName string      `json:"quotaPoolName" v:"required" dc:"配额池名称" example:"itso-deep-research-vip"`
+	Reason        string      `json:"reason" v:"in:charge,cron_reset,manual_reset,top_up,manual_adjust,rule_sync,transfer_in,transfer_out,grant_expire" dc:"变动原因，不传则返回全部"`
+	StartTime     *gtime.Time `json:"startTime" dc:"开始时间（含）" example:"2025-01-01"`
+	EndTime       *gtime.Time `json:"endTime" dc:"结束时间（不含）" example:"2025-02-01"`
+	Page          int         `json:"page" v:"min:1" d:"1" dc:"页码，从1开始"`
+	PageSize      int         `json:"pageSize" v:"min:1|max:1000" d:"20" dc:"每页条数，最大1000"`
+}
+type GetQuotaPoolLedgerRes struct {
+	Items      []entity.QuotapoolLedger `json:"items" dc:"账本记录列表"`
+	Total      int                      `json:"total" dc:"总记录数"`
+	Page       int                      `json:"page" dc:"当前页码"`
+	PageSize   int                      `json:"pageSize" dc:"每页条数"`
+	TotalPages int                      `json:"totalPages" dc:"总页数"`
+}
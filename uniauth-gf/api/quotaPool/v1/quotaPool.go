@@ -5,6 +5,7 @@ import (
 
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/shopspring/decimal"
 )
 
@@ -74,20 +75,36 @@ type NewQuotaPoolReq struct {
 	ExtraQuota decimal.Decimal `json:"extraQuota" d:"0" example:"0" jsonschema:"type=string" jsonschema_description:"初始加油包配额，默认为 0"`
 	// ITTools 规则（可选）
 	UserinfosRules *gjson.Json `json:"userinfosRules" jsonschema_description:"ITTools 用户信息过滤规则（可选），用于动态匹配用户"`
+	// 每个用户每周期的消费上限（可选）
+	UserQuotaLimit *decimal.Decimal `json:"userQuotaLimit" example:"50" jsonschema:"type=string" jsonschema_description:"共享配额池内每个用户每周期的消费上限，小于 0 或不传表示不限制"`
+	// 个人限额统计周期（可选）
+	UserLimitCronCycle string `json:"userLimitCronCycle" example:"0 0 * * *" jsonschema_description:"个人限额的统计周期 Cron 表达式（5字段格式），为空时沿用 cronCycle"`
+	// 余额结转策略（可选）
+	RolloverPolicy string `json:"rolloverPolicy" v:"in:discard,carry_over,carry_debt" example:"carry_over" jsonschema:"enum=discard,enum=carry_over,enum=carry_debt" jsonschema_description:"刷新时对上一周期剩余配额的处理：discard 清零（默认），carry_over 结余结转到下一周期（欠费免除），carry_debt 欠费从下一周期扣除（结余清零）"`
+	// 结余结转上限（可选）
+	RolloverCapPercent *decimal.Decimal `json:"rolloverCapPercent" example:"50" jsonschema:"type=string" jsonschema_description:"carry_over 时结转金额的上限，定期配额的百分比，小于 0 或不传表示不限制"`
+	RolloverCapAmount  *decimal.Decimal `json:"rolloverCapAmount" example:"500" jsonschema:"type=string" jsonschema_description:"carry_over 时结转金额的上限，绝对值，小于 0 或不传表示不限制。与百分比上限同时设置时取较小者"`
+	// 所属域（可选）
+	Domain string `json:"domain" example:"default" jsonschema_description:"配额池所属的 Casbin 域，例如部门名称。配额池的用户继承关系建立在这个域中，创建后不能修改。管理员调用时必须与授权的域 dom 一致，不传则使用 dom；否则默认为 default"`
 }
 type NewQuotaPoolRes struct {
 	OK bool `json:"ok" dc:"是否成功"`
 }
 
 type EditQuotaPoolReq struct {
-	g.Meta         `path:"/" tags:"QuotaPool" method:"put" summary:"编辑配额池" dc:"除了 quotaPoolName 字段必传之外，其他字段可以不传。不传的字段不会更新。"`
-	QuotaPoolName  string           `json:"quotaPoolName" v:"required"`
-	CronCycle      *string          `json:"cronCycle"`
-	RegularQuota   *decimal.Decimal `json:"regularQuota"`
-	Personal       *bool            `json:"personal"`
-	Disabled       *bool            `json:"disabled"`
-	ExtraQuota     *decimal.Decimal `json:"extraQuota"`
-	UserinfosRules *gjson.Json      `json:"userinfosRules"`
+	g.Meta             `path:"/" tags:"QuotaPool" method:"put" summary:"编辑配额池" dc:"除了 quotaPoolName 字段必传之外，其他字段可以不传。不传的字段不会更新。"`
+	QuotaPoolName      string           `json:"quotaPoolName" v:"required"`
+	CronCycle          *string          `json:"cronCycle"`
+	RegularQuota       *decimal.Decimal `json:"regularQuota"`
+	Personal           *bool            `json:"personal"`
+	Disabled           *bool            `json:"disabled"`
+	ExtraQuota         *decimal.Decimal `json:"extraQuota"`
+	UserinfosRules     *gjson.Json      `json:"userinfosRules"`
+	UserQuotaLimit     *decimal.Decimal `json:"userQuotaLimit" dc:"每个用户每周期的消费上限，小于 0 表示不限制"`
+	UserLimitCronCycle *string          `json:"userLimitCronCycle" dc:"个人限额的统计周期，传空字符串则沿用 cronCycle"`
+	RolloverPolicy     *string          `json:"rolloverPolicy" v:"in:discard,carry_over,carry_debt" dc:"余额结转策略：discard | carry_over | carry_debt"`
+	RolloverCapPercent *decimal.Decimal `json:"rolloverCapPercent" dc:"结余结转上限，定期配额的百分比，小于 0 表示不限制"`
+	RolloverCapAmount  *decimal.Decimal `json:"rolloverCapAmount" dc:"结余结转上限，绝对值，小于 0 表示不限制"`
 }
 type EditQuotaPoolRes struct {
 	OK bool `json:"ok" dc:"是否成功"`
@@ -117,3 +134,22 @@ type RefreshUsersOfQuotaPoolReq struct {
 type RefreshUsersOfQuotaPoolRes struct {
 	OK bool `json:"ok" v:"required" dc:"是否成功"`
 }
+
+type UserAllowanceItem struct {
+	Upn         string          `json:"upn" dc:"UPN"`
+	Limited     bool            `json:"limited" dc:"配额池是否设置了个人限额"`
+	Limit       decimal.Decimal `json:"limit" dc:"每周期个人限额，未设置时为 -1"`
+	Used        decimal.Decimal `json:"used" dc:"本周期已消费"`
+	Held        decimal.Decimal `json:"held" dc:"进行中的预留冻结额度"`
+	Remaining   decimal.Decimal `json:"remaining" dc:"本周期剩余可用额度，未设置限额时为 -1"`
+	PeriodStart *gtime.Time     `json:"periodStart" dc:"本统计周期的开始时间"`
+}
+
+type GetUserAllowanceReq struct {
+	g.Meta        `path:"/userAllowance" tags:"QuotaPool" method:"post" summary:"查询用户在配额池内的个人限额" dc:"查询共享配额池内每个用户本周期的个人限额使用情况。不传 upns 则返回配额池的所有用户。"`
+	QuotaPoolName string   `json:"quotaPoolName" v:"required" dc:"配额池名称" example:"itso-deep-research-vip"`
+	Upns          []string `json:"upns" dc:"UPN 列表，不传则查询配额池的所有用户"`
+}
+type GetUserAllowanceRes struct {
+	Items []*UserAllowanceItem `json:"items" dc:"个人限额使用情况列表"`
+}
@@ -0,0 +1,240 @@
+// Command mockidp 是一个本地的 OIDC 身份提供方，用于在开发和测试环境验证管理后台的单点登录。
+//
+//	go run ./hack/mockidp -port 8800
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcmd"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// mockIdpKid 模拟身份提供方签名公钥的 kid
+const mockIdpKid = "mock-idp"
+
+// mockIdpLoginPage 模拟身份提供方的登录页面，输入 UPN 即可登录，不校验密码
+var mockIdpLoginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Mock IdP</title></head>
+<body>
+<h3>Mock IdP：输入要登录的 UPN</h3>
+<form method="post" action="authorize">
+{{range $k, $v := .}}<input type="hidden" name="{{$k}}" value="{{index $v 0}}">
+{{end}}<input name="login_hint" placeholder="alice@cuhk.edu.cn" autofocus>
+<button type="submit">登录</button>
+</form>
+</body></html>`))
+
+// mockIdpCode 已签发、还没有换取令牌的授权码
+type mockIdpCode struct {
+	upn           string
+	clientId      string
+	redirectUri   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+func main() {
+	mockIdp.Run(gctx.GetInitCtx())
+}
+
+var (
+	mockIdp = gcmd.Command{
+		Name:  "mockidp",
+		Usage: "mockidp [-port 8800] [-client uniauth] [-secret secret]",
+		Brief: "start a local OIDC identity provider for testing single sign-on",
+		Description: `启动一个本地的 OIDC 身份提供方，用于在开发和测试环境验证单点登录。
+登录页面输入 UPN 即可登录，不校验密码；授权请求带 login_hint 参数时直接以该 UPN 登录。
+签发的 ID Token 使用启动时生成的 RSA 密钥签名，包含 upn、name 和 email 声明。
+
+配合使用的配置：
+  oidc.enabled: true
+  oidc.issuer: http://127.0.0.1:8800
+  oidc.clientId / oidc.clientSecret: 与 -client / -secret 一致
+  oidc.redirectUrl: http://<本服务地址>/auth/uniauth/oidc/callback`,
+		Arguments: []gcmd.Argument{
+			{Name: "port", Short: "p", Brief: "监听端口，默认 8800"},
+			{Name: "client", Short: "c", Brief: "允许的 client_id，默认 uniauth"},
+			{Name: "secret", Short: "s", Brief: "client_secret，默认 secret"},
+		},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			port := parser.GetOpt("port", 8800).Int()
+			clientId := parser.GetOpt("client", "uniauth").String()
+			clientSecret := parser.GetOpt("secret", "secret").String()
+			issuer := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				return gerror.Wrap(err, "生成签名密钥失败")
+			}
+			var (
+				mu    sync.Mutex
+				codes = map[string]*mockIdpCode{}
+			)
+
+			s := g.Server("mock-idp")
+			s.BindHandler("GET:/.well-known/openid-configuration", func(r *ghttp.Request) {
+				r.Response.WriteJson(g.Map{
+					"issuer":                                issuer,
+					"authorization_endpoint":                issuer + "/authorize",
+					"token_endpoint":                        issuer + "/token",
+					"jwks_uri":                              issuer + "/jwks",
+					"response_types_supported":              []string{"code"},
+					"subject_types_supported":               []string{"public"},
+					"id_token_signing_alg_values_supported": []string{"RS256"},
+					"code_challenge_methods_supported":      []string{"S256"},
+				})
+			})
+			s.BindHandler("GET:/jwks", func(r *ghttp.Request) {
+				r.Response.WriteJson(g.Map{"keys": []g.Map{{
+					"kty": "RSA",
+					"kid": mockIdpKid,
+					"use": "sig",
+					"alg": "RS256",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				}}})
+			})
+			s.BindHandler("/authorize", func(r *ghttp.Request) {
+				query := url.Values{}
+				for _, name := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+					query.Set(name, r.Get(name).String())
+				}
+				if query.Get("client_id") != clientId || query.Get("redirect_uri") == "" {
+					r.Response.WriteStatus(http.StatusBadRequest, "client_id 或 redirect_uri 无效")
+					return
+				}
+				if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+					r.Response.WriteStatus(http.StatusBadRequest, "需要 S256 的 PKCE code_challenge")
+					return
+				}
+				upn := strings.TrimSpace(r.Get("login_hint").String())
+				if upn == "" {
+					r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+					if err := mockIdpLoginPage.Execute(r.Response.Writer, query); err != nil {
+						g.Log().Error(r.Context(), "渲染登录页面失败:", err)
+					}
+					return
+				}
+
+				buf := make([]byte, 16)
+				_, _ = rand.Read(buf)
+				code := base64.RawURLEncoding.EncodeToString(buf)
+				mu.Lock()
+				codes[code] = &mockIdpCode{
+					upn:           upn,
+					clientId:      query.Get("client_id"),
+					redirectUri:   query.Get("redirect_uri"),
+					nonce:         query.Get("nonce"),
+					codeChallenge: query.Get("code_challenge"),
+					expiresAt:     time.Now().Add(time.Minute),
+				}
+				mu.Unlock()
+				g.Log().Infof(r.Context(), "Mock IdP：%v 登录，签发授权码", upn)
+
+				callback, _ := url.Parse(query.Get("redirect_uri"))
+				q := callback.Query()
+				q.Set("code", code)
+				q.Set("state", query.Get("state"))
+				callback.RawQuery = q.Encode()
+				r.Response.RedirectTo(callback.String())
+			})
+			s.BindHandler("POST:/token", func(r *ghttp.Request) {
+				tokenError := func(desc string) {
+					r.Response.WriteHeader(http.StatusBadRequest)
+					r.Response.WriteJson(g.Map{"error": "invalid_grant", "error_description": desc})
+				}
+				if r.Get("grant_type").String() != "authorization_code" {
+					tokenError("只支持 authorization_code")
+					return
+				}
+				if r.Get("client_id").String() != clientId || r.Get("client_secret").String() != clientSecret {
+					tokenError("client_id 或 client_secret 错误")
+					return
+				}
+				code := r.Get("code").String()
+				mu.Lock()
+				grant := codes[code]
+				delete(codes, code)
+				mu.Unlock()
+				if grant == nil || grant.expiresAt.Before(time.Now()) {
+					tokenError("授权码无效或已过期")
+					return
+				}
+				if r.Get("redirect_uri").String() != grant.redirectUri {
+					tokenError("redirect_uri 与授权请求不一致")
+					return
+				}
+				challenge := sha256.Sum256([]byte(r.Get("code_verifier").String()))
+				if base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.codeChallenge {
+					tokenError("code_verifier 校验失败")
+					return
+				}
+
+				now := time.Now()
+				idToken, err := mockIdpSign(key, g.Map{
+					"iss":   issuer,
+					"sub":   grant.upn,
+					"aud":   grant.clientId,
+					"iat":   now.Unix(),
+					"exp":   now.Add(5 * time.Minute).Unix(),
+					"nonce": grant.nonce,
+					"upn":   grant.upn,
+					"email": grant.upn,
+					"name":  strings.Split(grant.upn, "@")[0],
+				})
+				if err != nil {
+					r.Response.WriteStatus(http.StatusInternalServerError, err.Error())
+					return
+				}
+				r.Response.WriteJson(g.Map{
+					"access_token": idToken,
+					"token_type":   "Bearer",
+					"expires_in":   300,
+					"id_token":     idToken,
+				})
+			})
+			s.SetPort(port)
+			g.Log().Infof(ctx, "Mock IdP 已启动，issuer：%v，client_id：%v", issuer, clientId)
+			s.Run()
+			return nil
+		},
+	}
+)
+
+// mockIdpSign 用 RS256 签发 JWT
+func mockIdpSign(key *rsa.PrivateKey, claims g.Map) (string, error) {
+	header, err := json.Marshal(g.Map{"alg": "RS256", "typ": "JWT", "kid": mockIdpKid})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", gerror.Wrap(err, "签发 ID Token 失败")
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
@@ -18,8 +18,13 @@ import (
 	"uniauth-gf/internal/controller/config"
 	"uniauth-gf/internal/controller/quotaPool"
 	"uniauth-gf/internal/controller/userinfos"
+	adminSvc "uniauth-gf/internal/service/admin"
+	casbinSvc "uniauth-gf/internal/service/casbin"
+	"uniauth-gf/internal/service/exchangeRate"
 	mcpSvc "uniauth-gf/internal/service/mcp"
+	"uniauth-gf/internal/service/poolAlert"
 	quotaPoolSvc "uniauth-gf/internal/service/quotaPool"
+	"uniauth-gf/internal/service/rateLimit"
 
 	"uniauth-gf/internal/middlewares"
 )
@@ -45,6 +50,14 @@ var (
 				panic(err)
 			}
 
+			// 没有管理员账号时，用配置中的账号创建初始管理员
+			if err := adminSvc.EnsureBootstrapAdmin(ctx); err != nil {
+				panic(err)
+			}
+
+			// 启动时构建汇率来源，配置有误或没有配置时在日志中提示
+			exchangeRate.GetProvider(ctx)
+
 			go func() {
 				if err := mcpSvc.StartMCPServer(ctx); err != nil {
 					g.Log().Error(ctx, "MCP服务器启动失败:", err)
@@ -60,6 +73,61 @@ var (
 				// 注册失败
 				panic(err)
 			}
+			if _, err = gcron.AddSingleton(ctx, "@every 1m", func(ctx context.Context) {
+				if _, err := quotaPoolSvc.ResetDueQuotaPools(ctx); err != nil {
+					g.Log().Error(ctx, "定时刷新配额池失败:", err)
+				}
+			}, "Reset Due QuotaPools"); err != nil {
+				panic(err)
+			}
+			if _, err = gcron.Add(ctx, "@every 1m", func(ctx context.Context) {
+				if _, err := quotaPoolSvc.ExpireReservations(ctx); err != nil {
+					g.Log().Error(ctx, "过期配额预留失败:", err)
+				}
+			}, "Expire QuotaPool Reservations"); err != nil {
+				panic(err)
+			}
+			if _, err = gcron.Add(ctx, "@every 5m", func(ctx context.Context) {
+				if _, err := quotaPoolSvc.SweepExpiredExtraGrants(ctx); err != nil {
+					g.Log().Error(ctx, "过期加油包失败:", err)
+				}
+			}, "Sweep Expired Extra Quota Grants"); err != nil {
+				panic(err)
+			}
+			if _, err = gcron.AddSingleton(ctx, "@every 10s", func(ctx context.Context) {
+				if _, err := poolAlert.DeliverPending(ctx); err != nil {
+					g.Log().Error(ctx, "投递配额池告警失败:", err)
+				}
+			}, "Deliver QuotaPool Alerts"); err != nil {
+				panic(err)
+			}
+			if _, err = gcron.AddSingleton(ctx, "@every 1m", func(ctx context.Context) {
+				if _, err := casbinSvc.PurgeExpiredRules(ctx); err != nil {
+					g.Log().Error(ctx, "清理过期权限规则失败:", err)
+				}
+				if _, err := casbinSvc.RefreshActivatedRules(ctx); err != nil {
+					g.Log().Error(ctx, "刷新已到生效时间的权限规则失败:", err)
+				}
+			}, "Purge Expired Casbin Rules"); err != nil {
+				panic(err)
+			}
+			if _, err = gcron.AddSingleton(ctx, "@hourly", func(ctx context.Context) {
+				if _, err := adminSvc.PurgeExpiredSessions(ctx); err != nil {
+					g.Log().Error(ctx, "清理过期管理员会话失败:", err)
+				}
+				if _, err := adminSvc.PurgeExpiredSsoStates(ctx); err != nil {
+					g.Log().Error(ctx, "清理过期单点登录请求失败:", err)
+				}
+			}, "Purge Expired Admin Sessions"); err != nil {
+				panic(err)
+			}
+			if _, err = gcron.AddSingleton(ctx, "@hourly", func(ctx context.Context) {
+				if _, err := rateLimit.PurgeIdleBuckets(ctx); err != nil {
+					g.Log().Error(ctx, "清理限流令牌桶失败:", err)
+				}
+			}, "Purge Idle Rate Limit Buckets"); err != nil {
+				panic(err)
+			}
 
 			s := g.Server()
 
@@ -67,32 +135,42 @@ var (
 			s.Use(ghttp.MiddlewareCORS)
 
 			s.Use(middlewares.UniResMiddleware)
+			// 管理接口需要管理员登录，并由管理员自己的 Casbin 权限决定能调用哪些接口
+			s.Use(middlewares.AdminRoutesMiddleware)
+			// 服务间调用通过 API Key 鉴权，Key 只能调用其路由组内、且服务身份在 Casbin 中有权限的接口；
+			// 鉴权之后按调用方和接口路径限流
 			s.Group("/userinfos", func(group *ghttp.RouterGroup) {
+				group.Middleware(middlewares.ServiceAuthMiddleware, middlewares.RateLimitMiddleware)
 				group.Bind(
 					userinfos.NewV1(),
 				)
 			})
 			s.Group("/auth", func(group *ghttp.RouterGroup) {
+				group.Middleware(middlewares.ServiceAuthMiddleware, middlewares.RateLimitMiddleware)
 				group.Bind(
 					auth.NewV1(),
 				)
 			})
 			s.Group("/billing", func(group *ghttp.RouterGroup) {
+				group.Middleware(middlewares.ServiceAuthMiddleware, middlewares.RateLimitMiddleware)
 				group.Bind(
 					billing.NewV1(),
 				)
 			})
 			s.Group("/config", func(group *ghttp.RouterGroup) {
+				group.Middleware(middlewares.ServiceAuthMiddleware, middlewares.RateLimitMiddleware)
 				group.Bind(
 					config.NewV1(),
 				)
 			})
 			s.Group("/quotaPool", func(group *ghttp.RouterGroup) {
+				group.Middleware(middlewares.ServiceAuthMiddleware, middlewares.RateLimitMiddleware)
 				group.Bind(
 					quotaPool.NewV1(),
 				)
 			})
 			s.Group("/chat", func(group *ghttp.RouterGroup) {
+				group.Middleware(middlewares.ServiceAuthMiddleware, middlewares.RateLimitMiddleware)
 				group.Bind(
 					chat.NewV1(),
 				)
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcmd"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/usageRollup"
+)
+
+// 回填时每个事务重建的天数，避免长时间锁住汇总表
+const rollupChunkDays = 7
+
+var (
+	Rollup = gcmd.Command{
+		Name:  "rollup",
+		Usage: "rollup [-from 2025-01-01] [-to 2025-01-31]",
+		Brief: "rebuild daily billing usage rollup from raw billing records",
+		Description: `根据 billing_cost_records 重建 billing_usage_daily。
+不传 from 时从最早的计费记录开始回填，不传 to 时重建到今天为止。`,
+		Arguments: []gcmd.Argument{
+			{Name: "from", Short: "f", Brief: "起始日期（含），例如 2025-01-01"},
+			{Name: "to", Short: "t", Brief: "结束日期（含），例如 2025-01-31"},
+		},
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			if err = gtime.SetTimeZone("Asia/Shanghai"); err != nil {
+				return err
+			}
+			from, to := gtime.Now().StartOfDay(), gtime.Now().StartOfDay()
+			if v := parser.GetOpt("from"); v != nil {
+				if from, err = gtime.StrToTime(v.String()); err != nil {
+					return gerror.Wrapf(err, "from 日期格式错误：%v", v)
+				}
+			} else {
+				earliest, err := dao.BillingCostRecords.Ctx(ctx).Fields("MIN(created_at)").Value()
+				if err != nil {
+					return gerror.Wrap(err, "查询最早的计费记录失败")
+				}
+				if earliest.IsNil() {
+					g.Log().Info(ctx, "没有任何计费记录，无需回填")
+					return nil
+				}
+				from = earliest.GTime()
+			}
+			if v := parser.GetOpt("to"); v != nil {
+				if to, err = gtime.StrToTime(v.String()); err != nil {
+					return gerror.Wrapf(err, "to 日期格式错误：%v", v)
+				}
+			}
+
+			for chunkFrom := from.StartOfDay(); !chunkFrom.After(to); chunkFrom = chunkFrom.AddDate(0, 0, rollupChunkDays) {
+				chunkTo := chunkFrom.AddDate(0, 0, rollupChunkDays-1)
+				if chunkTo.After(to) {
+					chunkTo = to
+				}
+				rows, err := usageRollup.Rebuild(ctx, chunkFrom, chunkTo)
+				if err != nil {
+					return err
+				}
+				g.Log().Infof(ctx, "已重建 %v ~ %v 的每日用量汇总，共 %d 行", chunkFrom.Format("Y-m-d"), chunkTo.Format("Y-m-d"), rows)
+			}
+			return nil
+		},
+	}
+)
@@ -1,7 +1,23 @@
 package consts
 
 import (
+	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/shopspring/decimal"
 )
 
 var GAME_CURRENCY = decimal.NewFromInt(1)
+
+// CtxKeyAdminUsername 管理员鉴权中间件写入请求上下文的管理员用户名
+const CtxKeyAdminUsername = "adminUsername"
+
+// CtxKeyAdminDomain 管理员鉴权中间件写入请求上下文的授权域，按域隔离的管理接口只能操作这个域中的资源
+const CtxKeyAdminDomain = "adminDomain"
+
+// CtxKeyServiceName 服务 API Key 鉴权中间件写入请求上下文的服务身份
+const CtxKeyServiceName = "serviceName"
+
+// CtxKeyApiKeyId 服务 API Key 鉴权中间件写入请求上下文的 Key ID
+const CtxKeyApiKeyId = "apiKeyId"
+
+// CodeTooManyRequests 调用方被限流，UniResMiddleware 返回的 code 为 429
+var CodeTooManyRequests = gcode.New(429, "Too Many Requests", nil)
@@ -10,8 +10,16 @@ import (
 	"github.com/casbin/casbin/v2"
 )
 
-var e *casbin.Enforcer
+var e *casbin.SyncedEnforcer
 
 func init() {
 	e = casbinService.GetEnforcer()
 }
+
+// domainOrDefault 请求中没有传域时使用默认域
+func domainOrDefault(dom string) string {
+	if dom == "" {
+		return casbinService.DefaultDomain
+	}
+	return dom
+}
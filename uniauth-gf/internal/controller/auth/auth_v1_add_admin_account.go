@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"context"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/admin"
+)
+
+func (c *ControllerV1) AddAdminAccount(ctx context.Context, req *v1.AddAdminAccountReq) (res *v1.AddAdminAccountRes, err error) {
+	if err = admin.CreateAccount(ctx, req.Username, req.Password, req.DisplayName); err != nil {
+		return nil, err
+	}
+	return &v1.AddAdminAccountRes{Ok: true}, nil
+}
@@ -4,17 +4,17 @@ import (
 	"context"
 
 	"github.com/gogf/gf/v2/errors/gerror"
-	
+
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) AddGrouping(ctx context.Context, req *v1.AddGroupingReq) (res *v1.AddGroupingRes, err error) {
-	if req.Skip {
-		_, err = e.AddGroupingPoliciesEx(req.Groupings)
-	} else {
-		_, err = e.AddGroupingPolicies(req.Groupings)
-	}
+	groupings, err := casbinService.IntoDomain(casbinService.PTypeGrouping, req.Dom, req.Groupings)
 	if err != nil {
+		return nil, err
+	}
+	if _, err = casbinService.AddGroupingPoliciesWithin(ctx, "auth.AddGrouping", groupings, req.Skip, casbinService.Validity{From: req.ValidFrom, Until: req.ValidUntil}); err != nil {
 		return nil, gerror.Wrap(err, "添加 Grouping Policies 失败")
 	}
 	return
@@ -4,17 +4,17 @@ import (
 	"context"
 
 	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func (c *ControllerV1) AddPolicies(ctx context.Context, req *v1.AddPoliciesReq) (res *v1.AddPoliciesRes, err error) {
-	if req.Skip {
-		_, err = e.AddPoliciesEx(req.Policies)
-	} else {
-		_, err = e.AddPolicies(req.Policies)
-	}
+	policies, err := casbinService.IntoDomain(casbinService.PTypePolicy, req.Dom, req.Policies)
 	if err != nil {
+		return nil, err
+	}
+	if _, err = casbinService.AddPoliciesWithin(ctx, "auth.AddPolicies", policies, req.Skip, casbinService.Validity{From: req.ValidFrom, Until: req.ValidUntil}); err != nil {
 		return nil, gerror.Wrap(err, "添加规则时 Casbin 发生内部错误")
 	}
 	return
@@ -5,6 +5,8 @@ import (
 
 	v1 "uniauth-gf/api/auth/v1"
 	"uniauth-gf/internal/dao"
+	casbinService "uniauth-gf/internal/service/casbin"
+	"uniauth-gf/internal/service/quotaPool"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
@@ -16,9 +18,11 @@ import (
 
 2. 检查配额池是否被禁用；
 
-3. 检查用户有没有权限使用这个配额池；
+3. 检查用户有没有权限使用这个配额池，即在配额池所属的域中继承了这个配额池；
 
-4. 检查配额池有没有权限使用这个 Svc 和 Product。
+4. 检查配额池有没有权限使用这个 Svc 和 Product，在配额池所属的域中判定；
+
+5. 检查用户在这个配额池内的个人限额是否用完。
 */
 func (c *ControllerV1) ChatPreCheckOneStop(ctx context.Context, req *v1.ChatPreCheckOneStopReq) (res *v1.ChatPreCheckOneStopRes, err error) {
 	res = &v1.ChatPreCheckOneStopRes{
@@ -43,7 +47,8 @@ func (c *ControllerV1) ChatPreCheckOneStop(ctx context.Context, req *v1.ChatPreC
 	}
 
 	// Step 3
-	has, err := e.HasGroupingPolicy(req.Upn, req.QuotaPool)
+	domain := record[dao.QuotapoolQuotaPool.Columns().Domain].String()
+	has, err := e.HasGroupingPolicy(req.Upn, req.QuotaPool, domain)
 	if err != nil {
 		err = gerror.Wrap(err, "Casbin在检查是否有角色继承关系时发生内部错误")
 		return
@@ -54,7 +59,8 @@ func (c *ControllerV1) ChatPreCheckOneStop(ctx context.Context, req *v1.ChatPreC
 	}
 
 	// Step 4
-	allow, err := e.Enforce(req.Upn, req.Svc+"/approach/"+req.Product, req.Act)
+	casbinService.PrepareAttributes(ctx, req.Upn)
+	allow, err := e.Enforce(req.Upn, domain, req.Svc+"/approach/"+req.Product, req.Act)
 	if err != nil {
 		err = gerror.Wrap(err, "Casbin在检查配额池策略时发生内部错误")
 		return
@@ -64,6 +70,17 @@ func (c *ControllerV1) ChatPreCheckOneStop(ctx context.Context, req *v1.ChatPreC
 		return
 	}
 
+	// Step 5
+	withinLimit, allowance, err := quotaPool.CheckUserAllowance(ctx, req.QuotaPool, req.Upn)
+	if err != nil {
+		err = gerror.Wrap(err, "检查个人限额时发生内部错误")
+		return
+	}
+	if !withinLimit {
+		err = gerror.Newf("该用户已用完本周期在这个配额池内的个人限额（%v），将于下个周期恢复", allowance.Limit)
+		return
+	}
+
 	res.Ok = true
 	return
 }
@@ -3,11 +3,13 @@ package auth
 import (
 	"context"
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) Check(ctx context.Context, req *v1.CheckReq) (res *v1.CheckRes, err error) {
 	res = &v1.CheckRes{}
-	res.Allow, err = e.Enforce(req.Sub, req.Obj, req.Act)
+	casbinService.PrepareAttributes(ctx, req.Sub)
+	res.Allow, err = e.Enforce(req.Sub, domainOrDefault(req.Dom), req.Obj, req.Act)
 	if err != nil {
 		return nil, err
 	}
@@ -2,14 +2,121 @@ package auth
 
 import (
 	"context"
-	"uniauth-gf/api/auth/v1"
+	"fmt"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
+// maxRoleDepth 展开角色继承的最大层数，与 Casbin 默认角色管理器一致
+const maxRoleDepth = 10
+
 func (c *ControllerV1) CheckAndExplain(ctx context.Context, req *v1.CheckAndExplainReq) (res *v1.CheckAndExplainRes, err error) {
-	res = &v1.CheckAndExplainRes{}
-	res.Allow, res.Reason, err = e.EnforceEx(req.Sub, req.Obj, req.Act)
+	// 属性条件需要的用户信息在拿锁之前读取
+	casbinService.PrepareAttributes(ctx, req.Sub)
+
+	// 判定和解释在同一个读锁下完成，保证两者基于同一份规则
+	lock := e.GetLock()
+	lock.RLock()
+	defer lock.RUnlock()
+
+	res = &v1.CheckAndExplainRes{
+		Roles:      []v1.RoleInheritance{},
+		AllowRules: []v1.MatchedRule{},
+		DenyRules:  []v1.MatchedRule{},
+	}
+	dom := domainOrDefault(req.Dom)
+	if res.Allow, res.Reason, err = e.Enforcer.EnforceEx(req.Sub, dom, req.Obj, req.Act); err != nil {
+		return nil, gerror.Wrapf(err, "检查 %v %v %v %v 失败", req.Sub, dom, req.Obj, req.Act)
+	}
+
+	// 1. 展开对象在域中继承的所有角色，记录到达每个角色的最短路径
+	paths := map[string][]string{req.Sub: {req.Sub}}
+	subjects := []string{req.Sub}
+	rm := e.Enforcer.GetRoleManager()
+	for depth, frontier := 0, []string{req.Sub}; depth < maxRoleDepth && len(frontier) > 0; depth++ {
+		var next []string
+		for _, name := range frontier {
+			roles, err := rm.GetRoles(name, dom)
+			if err != nil {
+				return nil, gerror.Wrapf(err, "查询 %v 继承的角色失败", name)
+			}
+			for _, role := range roles {
+				if _, ok := paths[role]; ok {
+					continue
+				}
+				paths[role] = append(append([]string{}, paths[name]...), role)
+				subjects = append(subjects, role)
+				next = append(next, role)
+				res.Roles = append(res.Roles, v1.RoleInheritance{Role: role, Path: paths[role]})
+			}
+		}
+		frontier = next
+	}
+	// 属性条件规则不通过 g 规则继承，对象的用户信息满足条件时直接适用
+	domainPolicies, err := e.Enforcer.GetFilteredPolicy(casbinService.PolicyDomainIndex, dom)
 	if err != nil {
-		panic(err)
+		return nil, gerror.Wrapf(err, "查询域 %v 的规则失败", dom)
+	}
+	for _, policy := range domainPolicies {
+		condition := policy[0]
+		if _, ok := paths[condition]; ok || !casbinService.IsAttributeSubject(condition) {
+			continue
+		}
+		// 判定出错的条件在鉴权时按不匹配处理，这里同样跳过
+		if matched, err := casbinService.AttributeMatch(req.Sub, condition); err == nil && matched {
+			paths[condition] = []string{req.Sub, condition}
+			subjects = append(subjects, condition)
+		}
+	}
+
+	// 2. 找出所有 sub 在继承链上、dom、obj 和 act 匹配的规则
+	sameObj := 0  // 资源匹配但动作不匹配的规则数，用于说明拒绝原因
+	inactive := 0 // 匹配但不在有效期内的规则数
+	for _, sub := range subjects {
+		policies, err := e.Enforcer.GetFilteredPolicy(0, sub, dom)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "查询 %v 的规则失败", sub)
+		}
+		for _, policy := range policies {
+			if len(policy) < 5 || !util.KeyMatch(req.Obj, policy[2]) {
+				continue
+			}
+			if policy[3] != req.Act {
+				sameObj++
+				continue
+			}
+			if !casbinService.RuleActive(casbinService.PTypePolicy, policy) {
+				inactive++
+				continue
+			}
+			matched := v1.MatchedRule{Rule: policy, Path: paths[sub]}
+			if policy[4] == "deny" {
+				res.DenyRules = append(res.DenyRules, matched)
+			} else if policy[4] == "allow" {
+				res.AllowRules = append(res.AllowRules, matched)
+			}
+		}
+	}
+
+	// 3. 说明判定结果
+	switch {
+	case len(res.DenyRules) > 0:
+		res.Explanation = fmt.Sprintf("拒绝：%d 条 deny 规则覆盖了 %d 条 allow 规则，第一条 deny 规则为 %v", len(res.DenyRules), len(res.AllowRules), strings.Join(res.DenyRules[0].Rule, ", "))
+	case len(res.AllowRules) > 0:
+		res.Explanation = fmt.Sprintf("允许：匹配 %d 条 allow 规则，没有 deny 规则", len(res.AllowRules))
+	case inactive > 0:
+		res.Explanation = fmt.Sprintf("拒绝：没有匹配的规则。继承链上有 %d 条匹配的规则不在有效期内", inactive)
+	case sameObj > 0:
+		res.Explanation = fmt.Sprintf("拒绝：没有匹配的规则。继承链上有 %d 条规则匹配资源 %v，但动作都不是 %v", sameObj, req.Obj, req.Act)
+	case len(res.Roles) == 0:
+		res.Explanation = fmt.Sprintf("拒绝：没有匹配的规则。%v 在域 %v 中没有继承任何角色，也没有直接授予的规则", req.Sub, dom)
+	default:
+		res.Explanation = fmt.Sprintf("拒绝：没有匹配的规则。%v 及其在域 %v 中继承的 %d 个角色都没有匹配资源 %v 的规则", req.Sub, dom, len(res.Roles), req.Obj)
 	}
 	return
 }
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+// checkBatchLimit 单次批量检查的最大请求数
+const checkBatchLimit = 1000
+
+func (c *ControllerV1) CheckBatch(ctx context.Context, req *v1.CheckBatchReq) (res *v1.CheckBatchRes, err error) {
+	if len(req.Requests) == 0 && req.ListObjects == nil {
+		return nil, gerror.New("requests 和 listObjects 至少需要传一个")
+	}
+	if len(req.Requests) > checkBatchLimit {
+		return nil, gerror.Newf("单次最多检查 %d 组，当前 %d 组", checkBatchLimit, len(req.Requests))
+	}
+
+	// 属性条件需要的用户信息在拿锁之前读取
+	subs := make([]string, 0, len(req.Requests)+1)
+	for _, tuple := range req.Requests {
+		subs = append(subs, tuple.Sub)
+	}
+	if req.ListObjects != nil {
+		subs = append(subs, req.ListObjects.Sub)
+	}
+	casbinService.PrepareAttributes(ctx, subs...)
+
+	// 所有检查在同一个读锁下完成，保证结果对应同一份规则。
+	// 持锁期间只能调用内嵌的 Enforcer，SyncedEnforcer 的方法会再次加锁。
+	lock := e.GetLock()
+	lock.RLock()
+	defer lock.RUnlock()
+
+	res = &v1.CheckBatchRes{Results: make([]v1.CheckBatchResult, len(req.Requests))}
+	if req.Explain {
+		for i, tuple := range req.Requests {
+			tuple.Dom = domainOrDefault(tuple.Dom)
+			res.Results[i].CheckTuple = tuple
+			if res.Results[i].Allow, res.Results[i].Reason, err = e.Enforcer.EnforceEx(tuple.Sub, tuple.Dom, tuple.Obj, tuple.Act); err != nil {
+				return nil, gerror.Wrapf(err, "检查 %v %v %v %v 失败", tuple.Sub, tuple.Dom, tuple.Obj, tuple.Act)
+			}
+		}
+	} else if len(req.Requests) > 0 {
+		requests := make([][]interface{}, len(req.Requests))
+		for i := range req.Requests {
+			req.Requests[i].Dom = domainOrDefault(req.Requests[i].Dom)
+			tuple := req.Requests[i]
+			requests[i] = []interface{}{tuple.Sub, tuple.Dom, tuple.Obj, tuple.Act}
+		}
+		allows, err := e.Enforcer.BatchEnforce(requests)
+		if err != nil {
+			return nil, gerror.Wrap(err, "批量检查失败")
+		}
+		for i, tuple := range req.Requests {
+			res.Results[i].CheckTuple = tuple
+			res.Results[i].Allow = allows[i]
+		}
+	}
+
+	if query := req.ListObjects; query != nil {
+		if res.Objects, err = listObjects(query); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// listObjects 在域中现有规则出现过的具体资源中，找出匹配前缀且对象可以执行该动作的资源。调用方需要持有读锁。
+func listObjects(query *v1.ListObjectsQuery) ([]string, error) {
+	dom := domainOrDefault(query.Dom)
+	policies, err := e.Enforcer.GetFilteredPolicy(casbinService.PolicyDomainIndex, dom)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取所有资源失败")
+	}
+	objects := make([]string, 0)
+	seen := make(map[string]struct{}, len(policies))
+	for _, policy := range policies {
+		obj := policy[2]
+		if _, ok := seen[obj]; ok || strings.Contains(obj, "*") {
+			continue
+		}
+		seen[obj] = struct{}{}
+		if query.Prefix != "" && !util.KeyMatch(obj, query.Prefix) {
+			continue
+		}
+		allow, err := e.Enforcer.Enforce(query.Sub, dom, obj, query.Act)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "检查 %v %v %v %v 失败", query.Sub, dom, obj, query.Act)
+		}
+		if allow {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/consts"
+	"uniauth-gf/internal/service/apiKey"
+)
+
+func (c *ControllerV1) CreateApiKey(ctx context.Context, req *v1.CreateApiKeyReq) (res *v1.CreateApiKeyRes, err error) {
+	key, record, err := apiKey.Create(ctx, &apiKey.NewKeyParams{
+		Service:     req.Service,
+		Scopes:      req.Scopes,
+		Description: req.Description,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedBy:   g.RequestFromCtx(ctx).GetCtxVar(consts.CtxKeyAdminUsername).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.CreateApiKeyRes{Key: key}
+	if err = gconv.Struct(record, &res.Item); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/util/gconv"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) CreateSnapshot(ctx context.Context, req *v1.CreateSnapshotReq) (res *v1.CreateSnapshotRes, err error) {
+	snapshot, err := casbinService.TakeSnapshot(ctx, casbinService.SnapshotManual, req.Name, "")
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.CreateSnapshotRes{}
+	if err = gconv.Struct(snapshot, &res.SnapshotItem); err != nil {
+		return nil, err
+	}
+	return
+}
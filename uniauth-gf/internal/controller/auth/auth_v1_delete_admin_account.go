@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"context"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/admin"
+)
+
+func (c *ControllerV1) DeleteAdminAccount(ctx context.Context, req *v1.DeleteAdminAccountReq) (res *v1.DeleteAdminAccountRes, err error) {
+	if err = admin.DeleteAccount(ctx, req.Username); err != nil {
+		return nil, err
+	}
+	return &v1.DeleteAdminAccountRes{Ok: true}, nil
+}
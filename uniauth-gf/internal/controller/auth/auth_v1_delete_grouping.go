@@ -6,10 +6,15 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) DeleteGrouping(ctx context.Context, req *v1.DeleteGroupingReq) (res *v1.DeleteGroupingRes, err error) {
-	if _, err := e.RemoveGroupingPolicies(req.Groupings); err != nil {
+	groupings, err := casbinService.IntoDomain(casbinService.PTypeGrouping, req.Dom, req.Groupings)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := casbinService.RemoveGroupingPolicies(ctx, "auth.DeleteGrouping", groupings); err != nil {
 		return nil, gerror.Wrap(err, "删除 Grouping Policies 失败")
 	}
 	return
@@ -4,12 +4,17 @@ import (
 	"context"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func (c *ControllerV1) DeletePolicies(ctx context.Context, req *v1.DeletePoliciesReq) (res *v1.DeletePoliciesRes, err error) {
-	if _, err := e.RemovePolicies(req.Policies); err != nil {
+	policies, err := casbinService.IntoDomain(casbinService.PTypePolicy, req.Dom, req.Policies)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := casbinService.RemovePolicies(ctx, "auth.DeletePolicies", policies); err != nil {
 		return nil, gerror.Wrap(err, "删除 Polices 失败")
 	}
 	return
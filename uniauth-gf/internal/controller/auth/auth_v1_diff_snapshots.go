@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) DiffSnapshots(ctx context.Context, req *v1.DiffSnapshotsReq) (res *v1.DiffSnapshotsRes, err error) {
+	diff, err := casbinService.DiffSnapshots(ctx, req.FromId, req.ToId)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.DiffSnapshotsRes{
+		Policies:  v1.RuleDiff(diff.Policies),
+		Groupings: v1.RuleDiff(diff.Groupings),
+	}, nil
+}
@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/admin"
+)
+
+func (c *ControllerV1) EditAdminAccount(ctx context.Context, req *v1.EditAdminAccountReq) (res *v1.EditAdminAccountRes, err error) {
+	if err = admin.UpdateAccount(ctx, req.Username, &admin.AccountUpdate{
+		Password:    req.Password,
+		DisplayName: req.DisplayName,
+		Disabled:    req.Disabled,
+	}); err != nil {
+		return nil, err
+	}
+	return &v1.EditAdminAccountRes{Ok: true}, nil
+}
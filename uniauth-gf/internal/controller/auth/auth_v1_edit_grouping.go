@@ -6,10 +6,15 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) EditGrouping(ctx context.Context, req *v1.EditGroupingReq) (res *v1.EditGroupingRes, err error) {
-	if _, err := e.UpdateGroupingPolicy(req.OldGrouping, req.NewGrouping); err != nil {
+	groupings, err := casbinService.IntoDomain(casbinService.PTypeGrouping, req.Dom, [][]string{req.OldGrouping, req.NewGrouping})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := casbinService.UpdateGroupingPolicy(ctx, "auth.EditGrouping", groupings[0], groupings[1]); err != nil {
 		return nil, gerror.Wrap(err, "编辑 Grouping Policies 失败")
 	}
 	return
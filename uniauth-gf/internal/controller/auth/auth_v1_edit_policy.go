@@ -4,12 +4,17 @@ import (
 	"context"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func (c *ControllerV1) EditPolicy(ctx context.Context, req *v1.EditPolicyReq) (res *v1.EditPolicyRes, err error) {
-	if _, err := e.UpdatePolicy(req.OldPolicy, req.NewPolicy); err != nil {
+	policies, err := casbinService.IntoDomain(casbinService.PTypePolicy, req.Dom, [][]string{req.OldPolicy, req.NewPolicy})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := casbinService.UpdatePolicy(ctx, "auth.EditPolicy", policies[0], policies[1]); err != nil {
 		return nil, gerror.Wrap(err, "编辑 Policy 失败")
 	}
 	return
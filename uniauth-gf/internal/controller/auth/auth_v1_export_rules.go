@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) ExportRules(ctx context.Context, req *v1.ExportRulesReq) (res *v1.ExportRulesRes, err error) {
+	policies, groupings, err := casbinService.ExportRules(casbinService.ExportFilter{
+		PType: req.PType,
+		Dom:   req.Dom,
+		Sub:   req.Sub,
+		Obj:   req.Obj,
+		Role:  req.Role,
+	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "导出规则失败")
+	}
+
+	r := g.RequestFromCtx(ctx)
+	if r == nil {
+		return nil, gerror.New("无法从上下文中获取请求对象")
+	}
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == casbinService.FormatJson {
+		contentType = "application/json"
+	}
+	filename := fmt.Sprintf("casbin-rules-%s.%s", time.Now().Format("20060102150405"), req.Format)
+	r.Response.Header().Set("Content-Type", contentType)
+	r.Response.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	if err = casbinService.WriteRules(r.Response.Writer, req.Format, policies, groupings); err != nil {
+		return nil, gerror.Wrap(err, "规则写入响应体失败")
+	}
+	return
+}
@@ -7,16 +7,18 @@ import (
 	"strings"
 
 	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) FilterGroupings(ctx context.Context, req *v1.FilterGroupingsReq) (res *v1.FilterGroupingsRes, err error) {
-	groupings, err := e.GetGroupingPolicy()
+	groupings, err := e.GetFilteredGroupingPolicy(casbinService.GroupingDomainIndex, req.Dom)
 	if err != nil {
 		return nil, err
 	}
 
+	// 返回的规则不含域字段
 	var resGroupings = [][]string{}
-	for _, grouping := range groupings {
+	for _, grouping := range casbinService.WithoutDomain(casbinService.PTypeGrouping, groupings) {
 		g1, g2 := grouping[0], grouping[1]
 		if strings.Contains(g1, req.G1) &&
 			strings.Contains(g2, req.G2) &&
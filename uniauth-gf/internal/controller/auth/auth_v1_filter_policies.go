@@ -7,18 +7,20 @@ import (
 	"strings"
 
 	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func (c *ControllerV1) FilterPolicies(ctx context.Context, req *v1.FilterPoliciesReq) (res *v1.FilterPoliciesRes, err error) {
-	policies, err := e.GetPolicy()
+	policies, err := e.GetFilteredPolicy(casbinService.PolicyDomainIndex, req.Dom)
 	if err != nil {
 		return nil, gerror.Wrap(err, "获取规则失败")
 	}
 
+	// 返回的规则不含域字段
 	var resPolicies = [][]string{}
-	for _, policy := range policies {
+	for _, policy := range casbinService.WithoutDomain(casbinService.PTypePolicy, policies) {
 		sub, obj, act, eft := policy[0], policy[1], policy[2], policy[3]
 		if strings.Contains(sub, req.Sub) &&
 			strings.Contains(obj, req.Obj) &&
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) GetAdminAccounts(ctx context.Context, req *v1.GetAdminAccountsReq) (res *v1.GetAdminAccountsRes, err error) {
+	res = &v1.GetAdminAccountsRes{Items: []v1.AdminAccountItem{}}
+	if err = dao.AdminAccount.Ctx(ctx).
+		FieldsEx("password_hash").
+		OrderAsc("username").
+		Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询管理员账号失败")
+	}
+	return
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) GetAllActions(ctx context.Context, req *v1.GetAllActionsReq) (res *v1.GetAllActionsRes, err error) {
 	res = &v1.GetAllActionsRes{}
-	res.Actions, err = e.GetAllActions()
+	res.Actions, err = casbinService.PolicyValuesInDomain(req.Dom, 3)
 	if err != nil {
 		return nil, err
 	}
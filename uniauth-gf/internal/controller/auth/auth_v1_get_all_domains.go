@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"context"
+
+	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) GetAllDomains(ctx context.Context, req *v1.GetAllDomainsReq) (res *v1.GetAllDomainsRes, err error) {
+	res = &v1.GetAllDomainsRes{}
+	res.Domains, err = casbinService.GetAllDomains()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) GetAllObjects(ctx context.Context, req *v1.GetAllObjectsReq) (res *v1.GetAllObjectsRes, err error) {
 	res = &v1.GetAllObjectsRes{}
-	res.Objects, err = e.GetAllObjects()
+	res.Objects, err = casbinService.PolicyValuesInDomain(req.Dom, 2)
 	if err != nil {
 		return nil, err
 	}
@@ -4,11 +4,12 @@ import (
 	"context"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) GetAllRoles(ctx context.Context, req *v1.GetAllRolesReq) (res *v1.GetAllRolesRes, err error) {
 	res = &v1.GetAllRolesRes{}
-	res.Roles, err = e.GetAllRoles()
+	res.Roles, err = casbinService.GetAllRolesInDomain(req.Dom)
 	if err != nil {
 		return nil, err
 	}
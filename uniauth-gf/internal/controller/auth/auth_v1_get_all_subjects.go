@@ -4,11 +4,12 @@ import (
 	"context"
 
 	"uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
 )
 
 func (c *ControllerV1) GetAllSubjects(ctx context.Context, req *v1.GetAllSubjectsReq) (res *v1.GetAllSubjectsRes, err error) {
 	res = &v1.GetAllSubjectsRes{}
-	res.Subjects, err = e.GetAllSubjects()
+	res.Subjects, err = casbinService.PolicyValuesInDomain(req.Dom, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -4,13 +4,18 @@ import (
 	"context"
 
 	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/quotaPool"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func (c *ControllerV1) GetAllUsersForQuotaPool(ctx context.Context, req *v1.GetAllUsersForQuotaPoolReq) (res *v1.GetAllUsersForQuotaPoolRes, err error) {
+	domain, err := quotaPool.GetDomain(ctx, req.QuotaPool)
+	if err != nil {
+		return nil, err
+	}
 	res = &v1.GetAllUsersForQuotaPoolRes{}
-	res.Users, err = e.GetUsersForRole(req.QuotaPool)
+	res.Users, err = e.GetUsersForRole(req.QuotaPool, domain)
 	if err != nil {
 		err = gerror.Wrap(err, "Casbin 查询拥有该 QuotaPool 的用户时发生内部错误")
 	}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) GetApiKeys(ctx context.Context, req *v1.GetApiKeysReq) (res *v1.GetApiKeysRes, err error) {
+	res = &v1.GetApiKeysRes{Items: []v1.ApiKeyItem{}}
+	model := dao.ServiceApiKey.Ctx(ctx).FieldsEx("key_hash")
+	if req.Service != "" {
+		model = model.Where("service", req.Service)
+	}
+	if req.Status != "" {
+		model = model.Where("status", req.Status)
+	}
+	if err = model.OrderDesc("created_at").OrderDesc("id").Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询服务 API Key 失败")
+	}
+	return
+}
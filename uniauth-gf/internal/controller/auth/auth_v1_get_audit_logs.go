@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"math"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) GetAuditLogs(ctx context.Context, req *v1.GetAuditLogsReq) (res *v1.GetAuditLogsRes, err error) {
+	model := dao.CasbinAuditLog.Ctx(ctx).
+		OmitEmpty().
+		Where("actor", req.Actor).
+		Where("action", req.Action).
+		Where("ptype", req.PType).
+		Where("operation", req.Operation).
+		Where("request_id", req.RequestId).
+		WhereGTE("created_at", req.StartTime).
+		WhereLT("created_at", req.EndTime)
+	if req.Keyword != "" {
+		keyword := "%" + req.Keyword + "%"
+		model = model.Where("(old_rules::text LIKE ? OR new_rules::text LIKE ?)", keyword, keyword)
+	}
+
+	total, err := model.Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询审计日志总数失败")
+	}
+
+	res = &v1.GetAuditLogsRes{
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+	}
+	if err = model.
+		OrderDesc("id").
+		Page(req.Page, req.PageSize).
+		Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询审计日志失败")
+	}
+	return
+}
@@ -5,12 +5,18 @@ import (
 	"strings"
 
 	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/quotaPool"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func (c *ControllerV1) GetAvailableModelForQuotaPool(ctx context.Context, req *v1.GetAvailableModelForQuotaPoolReq) (res *v1.GetAvailableModelForQuotaPoolRes, err error) {
-	policies, err := e.GetImplicitPermissionsForUser(req.QuotaPool)
+	domain, err := quotaPool.GetDomain(ctx, req.QuotaPool)
+	if err != nil {
+		return nil, err
+	}
+	// 规则为 sub, dom, obj, act, eft
+	policies, err := e.GetImplicitPermissionsForUser(req.QuotaPool, domain)
 	if err != nil {
 		err = gerror.Wrap(err, "Casbin 查询配额池权限时发生内部错误")
 	}
@@ -18,8 +24,8 @@ func (c *ControllerV1) GetAvailableModelForQuotaPool(ctx context.Context, req *v
 		AvailableModels: []string{},
 	}
 	for _, policy := range policies {
-		if strings.HasPrefix(policy[1], "chat/approach/") && policy[2] == "access" && policy[3] != "deny" {
-			res.AvailableModels = append(res.AvailableModels, strings.Split(policy[1], "/")[2])
+		if strings.HasPrefix(policy[2], "chat/approach/") && policy[3] == "access" && policy[4] != "deny" {
+			res.AvailableModels = append(res.AvailableModels, strings.Split(policy[2], "/")[2])
 		}
 	}
 
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"math"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) GetSnapshots(ctx context.Context, req *v1.GetSnapshotsReq) (res *v1.GetSnapshotsRes, err error) {
+	model := dao.CasbinPolicySnapshot.Ctx(ctx).
+		OmitEmpty().
+		Where("kind", req.Kind)
+
+	total, err := model.Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询规则快照总数失败")
+	}
+
+	res = &v1.GetSnapshotsRes{
+		Items:      []v1.SnapshotItem{},
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+	}
+	if err = model.
+		FieldsEx("policies, groupings").
+		OrderDesc("id").
+		Page(req.Page, req.PageSize).
+		Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询规则快照失败")
+	}
+	return
+}
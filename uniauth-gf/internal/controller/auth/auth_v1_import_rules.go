@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) ImportRules(ctx context.Context, req *v1.ImportRulesReq) (res *v1.ImportRulesRes, err error) {
+	format := req.Format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(req.File.Filename)), ".")
+	}
+	if format != casbinService.FormatCsv && format != casbinService.FormatJson {
+		return nil, gerror.Newf("无法判断文件 %v 的格式，请指定 format 为 csv 或 json", req.File.Filename)
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, gerror.Wrap(err, "打开上传的文件失败")
+	}
+	defer file.Close()
+	policies, groupings, validities, err := casbinService.ParseRules(file, format)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, backup, err := casbinService.ImportRules(ctx, policies, groupings, validities, req.Mode, req.DryRun)
+	if err != nil {
+		return nil, gerror.Wrap(err, "导入规则失败")
+	}
+	res = &v1.ImportRulesRes{
+		Applied:       !req.DryRun,
+		PolicyCount:   len(policies),
+		GroupingCount: len(groupings),
+		Policies:      v1.RuleDiff(diff.Policies),
+		Groupings:     v1.RuleDiff(diff.Groupings),
+	}
+	if backup != nil {
+		res.BackupSnapshotId = backup.Id
+	}
+	return
+}
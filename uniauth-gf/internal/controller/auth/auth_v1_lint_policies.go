@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) LintPolicies(ctx context.Context, req *v1.LintPoliciesReq) (res *v1.LintPoliciesRes, err error) {
+	report, err := casbinService.Lint(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "检查规则失败")
+	}
+	res = &v1.LintPoliciesRes{
+		Matcher:       report.Matcher,
+		PolicyCount:   report.PolicyCount,
+		GroupingCount: report.GroupingCount,
+		Counts:        report.Counts(),
+		Issues:        []v1.LintIssue{},
+	}
+	for _, issue := range report.Issues {
+		if (req.Kind != "" && issue.Kind != req.Kind) || (req.Severity != "" && issue.Severity != req.Severity) {
+			continue
+		}
+		res.Issues = append(res.Issues, v1.LintIssue(*issue))
+	}
+	return
+}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"context"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/apiKey"
+)
+
+func (c *ControllerV1) RevokeApiKey(ctx context.Context, req *v1.RevokeApiKeyReq) (res *v1.RevokeApiKeyRes, err error) {
+	if err = apiKey.Revoke(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	return &v1.RevokeApiKeyRes{Ok: true}, nil
+}
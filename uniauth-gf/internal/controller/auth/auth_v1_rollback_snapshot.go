@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) RollbackSnapshot(ctx context.Context, req *v1.RollbackSnapshotReq) (res *v1.RollbackSnapshotRes, err error) {
+	diff, backup, err := casbinService.RollbackToSnapshot(ctx, req.Id)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "回滚到规则快照 %v 失败", req.Id)
+	}
+	return &v1.RollbackSnapshotRes{
+		BackupSnapshotId: backup.Id,
+		Policies:         v1.RuleDiff(diff.Policies),
+		Groupings:        v1.RuleDiff(diff.Groupings),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/consts"
+	"uniauth-gf/internal/service/apiKey"
+)
+
+func (c *ControllerV1) RotateApiKey(ctx context.Context, req *v1.RotateApiKeyReq) (res *v1.RotateApiKeyRes, err error) {
+	key, record, err := apiKey.Rotate(ctx, req.Id, g.RequestFromCtx(ctx).GetCtxVar(consts.CtxKeyAdminUsername).String())
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.RotateApiKeyRes{Key: key}
+	if err = gconv.Struct(record, &res.Item); err != nil {
+		return nil, err
+	}
+	return
+}
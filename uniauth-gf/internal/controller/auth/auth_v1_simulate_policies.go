@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/auth/v1"
+	casbinService "uniauth-gf/internal/service/casbin"
+)
+
+func (c *ControllerV1) SimulatePolicies(ctx context.Context, req *v1.SimulatePoliciesReq) (res *v1.SimulatePoliciesRes, err error) {
+	tuples := make([]casbinService.AccessTuple, len(req.Requests))
+	for i, r := range req.Requests {
+		dom := r.Dom
+		if dom == "" {
+			dom = req.Dom
+		}
+		tuples[i] = casbinService.AccessTuple{Sub: r.Sub, Dom: dom, Obj: r.Obj, Act: r.Act}
+	}
+	change := &casbinService.ProposedChange{}
+	for _, section := range []struct {
+		ptype string
+		rules [][]string
+		to    *[][]string
+	}{
+		{casbinService.PTypePolicy, req.AddPolicies, &change.AddPolicies},
+		{casbinService.PTypePolicy, req.RemovePolicies, &change.RemovePolicies},
+		{casbinService.PTypeGrouping, req.AddGroupings, &change.AddGroupings},
+		{casbinService.PTypeGrouping, req.RemoveGroupings, &change.RemoveGroupings},
+	} {
+		if *section.to, err = casbinService.IntoDomain(section.ptype, req.Dom, section.rules); err != nil {
+			return nil, err
+		}
+	}
+	result, err := casbinService.Simulate(ctx, change, tuples)
+	if err != nil {
+		return nil, gerror.Wrap(err, "模拟规则修改失败")
+	}
+
+	res = &v1.SimulatePoliciesRes{
+		Checked: result.Checked,
+		Changes: make([]v1.AccessChange, 0, len(result.Changes)),
+		Policies: v1.RuleDiff{
+			Added:   casbinService.WithoutDomain(casbinService.PTypePolicy, result.Policies.Added),
+			Removed: casbinService.WithoutDomain(casbinService.PTypePolicy, result.Policies.Removed),
+		},
+		Groupings: v1.RuleDiff{
+			Added:   casbinService.WithoutDomain(casbinService.PTypeGrouping, result.Groupings.Added),
+			Removed: casbinService.WithoutDomain(casbinService.PTypeGrouping, result.Groupings.Removed),
+		},
+	}
+	for _, change := range result.Changes {
+		if change.After {
+			res.Gained++
+		} else {
+			res.Lost++
+		}
+		res.Changes = append(res.Changes, v1.AccessChange{
+			Sub:    change.Sub,
+			Dom:    change.Dom,
+			Obj:    change.Obj,
+			Act:    change.Act,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return
+}
@@ -2,18 +2,31 @@ package auth
 
 import (
 	"context"
-
-	"uniauth-gf/api/auth/v1"
+	"errors"
 
 	"github.com/gogf/gf/v2/frame/g"
+
+	"uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/admin"
 )
 
 func (c *ControllerV1) UniauthLogin(ctx context.Context, req *v1.UniauthLoginReq) (res *v1.UniauthLoginRes, err error) {
 	res = &v1.UniauthLoginRes{}
-	if req.Account == g.Cfg().MustGetWithEnv(ctx, "uniauth.account").String() && req.Password == g.Cfg().MustGetWithEnv(ctx, "uniauth.password").String() {
-		res.Ok = true
-	} else {
+	var clientIp, userAgent string
+	if r := g.RequestFromCtx(ctx); r != nil {
+		clientIp, userAgent = r.GetClientIp(), r.UserAgent()
+	}
+	token, session, err := admin.Login(ctx, req.Account, req.Password, clientIp, userAgent)
+	if errors.Is(err, admin.ErrInvalidCredentials) {
+		g.Log().Infof(ctx, "管理员 %v 登录失败：用户名或密码错误", req.Account)
 		res.Ok = false
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
 	}
+	res.Ok = true
+	res.Token = token
+	res.ExpiresAt = session.ExpiresAt
 	return
 }
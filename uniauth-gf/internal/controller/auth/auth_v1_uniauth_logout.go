@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/middlewares"
+	"uniauth-gf/internal/service/admin"
+)
+
+func (c *ControllerV1) UniauthLogout(ctx context.Context, req *v1.UniauthLogoutReq) (res *v1.UniauthLogoutRes, err error) {
+	if token := middlewares.BearerToken(g.RequestFromCtx(ctx)); token != "" {
+		if err = admin.Logout(ctx, token); err != nil {
+			return nil, err
+		}
+	}
+	return &v1.UniauthLogoutRes{Ok: true}, nil
+}
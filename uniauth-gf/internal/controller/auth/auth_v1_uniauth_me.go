@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/middlewares"
+	"uniauth-gf/internal/service/admin"
+)
+
+func (c *ControllerV1) UniauthMe(ctx context.Context, req *v1.UniauthMeReq) (res *v1.UniauthMeRes, err error) {
+	session, err := admin.Authenticate(ctx, middlewares.BearerToken(g.RequestFromCtx(ctx)))
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "未登录或会话已过期，请重新登录")
+	}
+	res = &v1.UniauthMeRes{
+		Username:  session.Username,
+		Provider:  session.Provider,
+		Subject:   admin.Subject(session.Username),
+		ExpiresAt: session.ExpiresAt,
+	}
+	account, err := admin.GetAccount(ctx, session.Username)
+	if err != nil {
+		return nil, err
+	}
+	if account != nil {
+		res.DisplayName = account.DisplayName
+		return
+	}
+	if session.Provider != admin.ProviderOidc {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "管理员账号不存在")
+	}
+	user, err := admin.GetSsoUser(ctx, session.Username)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		res.DisplayName = user.DisplayName
+	}
+	return
+}
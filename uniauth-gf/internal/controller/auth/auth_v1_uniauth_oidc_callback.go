@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/admin"
+)
+
+func (c *ControllerV1) UniauthOidcCallback(ctx context.Context, req *v1.UniauthOidcCallbackReq) (res *v1.UniauthOidcCallbackRes, err error) {
+	if req.Error != "" {
+		return nil, gerror.NewCodef(gcode.CodeNotAuthorized, "身份提供方登录失败：%v %v", req.Error, req.ErrorDescription)
+	}
+	if req.Code == "" {
+		return nil, gerror.NewCode(gcode.CodeMissingParameter, "缺少授权码")
+	}
+	r := g.RequestFromCtx(ctx)
+	token, session, redirect, err := admin.SsoCallback(ctx, req.Code, req.State, r.GetClientIp(), r.UserAgent())
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeNotAuthorized, err, "单点登录失败")
+	}
+	r.Response.Header().Set("Location", admin.SsoRedirectUrl(redirect, token, session))
+	r.Response.WriteHeader(http.StatusFound)
+	return
+}
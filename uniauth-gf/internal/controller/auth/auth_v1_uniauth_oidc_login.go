@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "uniauth-gf/api/auth/v1"
+	"uniauth-gf/internal/service/admin"
+)
+
+func (c *ControllerV1) UniauthOidcLogin(ctx context.Context, req *v1.UniauthOidcLoginReq) (res *v1.UniauthOidcLoginRes, err error) {
+	loginUrl, err := admin.SsoLoginUrl(ctx, req.Redirect)
+	if err != nil {
+		return nil, err
+	}
+	r := g.RequestFromCtx(ctx)
+	r.Response.Header().Set("Location", loginUrl)
+	r.Response.WriteHeader(http.StatusFound)
+	return
+}
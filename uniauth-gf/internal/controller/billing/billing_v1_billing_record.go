@@ -2,16 +2,23 @@ package billing
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 
 	v1 "uniauth-gf/api/billing/v1"
 	"uniauth-gf/internal/dao"
 	"uniauth-gf/internal/model/entity"
 	"uniauth-gf/internal/service/exchangeRate"
+	"uniauth-gf/internal/service/quotaPool"
+	"uniauth-gf/internal/service/usageRollup"
 )
 
 /*
@@ -24,6 +31,10 @@ import (
 2. 该用户有没有权限使用本产品，也不对 Svc 和 Product 的正确性做校验；
 
 3. 不校验本用户有没有权限使用本配额池。
+
+调用方重试时应携带相同的 request_id（幂等键）。同一个幂等键只会写入一条计费记录、扣一次费，
+重放请求直接返回首次计费的结果。同一个幂等键用于 upn、service、product 或 source 不同的请求时返回错误。
+计费记录写入和扣费在同一个事务中完成。
 */
 func (c *ControllerV1) BillingRecord(ctx context.Context, req *v1.BillingRecordReq) (res *v1.BillingRecordRes, err error) {
 	res = &v1.BillingRecordRes{}
@@ -34,58 +45,67 @@ func (c *ControllerV1) BillingRecord(ctx context.Context, req *v1.BillingRecordR
 		}
 	}()
 
-	// 根据配额池属性是否为个人自动判断计费方案
-	var qp *entity.QuotapoolQuotaPool
-	err = dao.QuotapoolQuotaPool.Ctx(ctx).Fields("personal, disabled").Where("quota_pool_name = ?", req.Source).Scan(&qp)
-	if err != nil {
-		err = gerror.Wrap(err, "获取配额池当前的剩余余额失败")
-		return
-	}
-	if qp == nil {
-		err = gerror.New("没有找到这个配额池。请重新检查")
-		return
-	}
-	if qp.Disabled {
-		err = gerror.New("这个配额池被禁用了，不能使用")
-		return
-	}
-
-	var plan string
-	if qp.Personal {
-		plan = "Included"
-	} else {
-		plan = "Quota Pool"
+	// 幂等键快速路径：已经计过费的请求直接返回首次计费的结果
+	if req.RequestId != "" {
+		var replayed bool
+		if replayed, err = findBillingRecordByRequestId(ctx, req, res); err != nil || replayed {
+			return
+		}
 	}
 
 	// 记录
-	var originalCost decimal.Decimal
-	var rate decimal.Decimal
-	if !req.USDCost.IsZero() {
-		// 算钱
-		rate, err = exchangeRate.GetExchangeRate(ctx, "USD", "CNY")
-		if err != nil {
-			err = gerror.Wrap(err, "获取汇率失败")
+	// 汇总各币种的费用，按当天汇率换算为本位货币
+	baseCurrency := exchangeRate.BaseCurrency(ctx)
+	costsByCurrency := make(map[string]decimal.Decimal)
+	currencies := make([]string, 0, len(req.Costs)+2)
+	addCost := func(currency string, amount decimal.Decimal) {
+		if amount.IsZero() {
 			return
 		}
+		currency = strings.ToUpper(currency)
+		if _, ok := costsByCurrency[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+		costsByCurrency[currency] = costsByCurrency[currency].Add(amount)
+	}
+	addCost("CNY", req.CNYCost)
+	addCost("USD", req.USDCost)
+	for _, item := range req.Costs {
+		addCost(item.Currency, item.Amount)
+	}
 
-		wrtErr := req.Remark.Set("USD", req.USDCost.String())
-		if wrtErr != nil {
-			g.Log().Infof(ctx, "计费流程中 USD 信息写入 Remark 失败。原始计费记录：%v", req)
+	originalCost := decimal.Zero
+	needsConversion := false
+	for _, currency := range currencies {
+		if currency != baseCurrency {
+			needsConversion = true
+		}
+	}
+	if needsConversion && req.Remark == nil {
+		req.Remark = gjson.New(g.Map{})
+	}
+	for _, currency := range currencies {
+		amount := costsByCurrency[currency]
+		if !needsConversion {
+			originalCost = originalCost.Add(amount)
+			continue
 		}
-		wrtErr = req.Remark.Set("USD_CNY_rate", rate.String())
-		if wrtErr != nil {
-			g.Log().Infof(ctx, "计费流程中 USD->CNY 汇率信息写入 Remark 失败。原始计费记录：%v", req)
+		// 有外币时，在 Remark 中记录原币金额和汇率
+		if wrtErr := req.Remark.Set(currency, amount.String()); wrtErr != nil {
+			g.Log().Infof(ctx, "计费流程中 %v 信息写入 Remark 失败。原始计费记录：%v", currency, req)
 		}
-		if !req.CNYCost.IsZero() {
-			wrtErr = req.Remark.Set("CNY", req.CNYCost.String())
-			if wrtErr != nil {
-				g.Log().Infof(ctx, "计费流程中 CNY 信息写入 Remark 失败。原始计费记录：%v", req)
+		if currency != baseCurrency {
+			var rate decimal.Decimal
+			amount, rate, err = exchangeRate.Convert(ctx, amount, currency, baseCurrency, gtime.Now())
+			if err != nil {
+				err = gerror.Wrap(err, "获取汇率失败")
+				return
+			}
+			if wrtErr := req.Remark.Set(currency+"_"+baseCurrency+"_rate", rate.String()); wrtErr != nil {
+				g.Log().Infof(ctx, "计费流程中 %v->%v 汇率信息写入 Remark 失败。原始计费记录：%v", currency, baseCurrency, req)
 			}
 		}
-
-		originalCost = req.CNYCost.Add(req.USDCost.Mul(rate))
-	} else {
-		originalCost = req.CNYCost
+		originalCost = originalCost.Add(amount)
 	}
 	cost := originalCost
 
@@ -106,43 +126,83 @@ func (c *ControllerV1) BillingRecord(ctx context.Context, req *v1.BillingRecordR
 		}
 	}
 
-	_, err = dao.BillingCostRecords.Ctx(ctx).Data(g.Map{
-		"upn":           req.Upn,
-		"svc":           req.Service,
-		"product":       req.Product,
-		"original_cost": originalCost,
-		"cost":          cost,
-		"plan":          plan,
-		"source":        req.Source,
-		"remark":        req.Remark,
-	}).Insert()
-	if err != nil {
-		return nil, gerror.Wrapf(err, "计费记录写入失败。终止扣费流程，没有扣费。计费信息：%v", req)
-	}
-
-	// 扣钱流程，使用事务
+	// 写入计费记录和扣费在同一个事务中完成，保证原子性
 	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
-		// 先获取基本余额和加油包的情况，用来计算应该扣多少
-		type oldQuota struct {
-			RemainingQuota decimal.Decimal
-			ExtraQuota     decimal.Decimal
+		// 先扣除到期的加油包，同时锁定配额池
+		if _, err := quotaPool.ExpireExtraGrants(ctx, req.Source); err != nil {
+			return gerror.Wrap(err, "扣费事务中，处理到期加油包失败")
 		}
-		var old_quota oldQuota
-		err := dao.QuotapoolQuotaPool.Ctx(ctx).Where("quota_pool_name = ?", req.Source).LockUpdate().Scan(&old_quota)
-		if err != nil {
+		// 锁定配额池，同一配额池的计费请求串行执行，幂等检查和写入之间不会被并发重放插队
+		var qp *entity.QuotapoolQuotaPool
+		if err := dao.QuotapoolQuotaPool.Ctx(ctx).Where("quota_pool_name = ?", req.Source).LockUpdate().Scan(&qp); err != nil {
 			return gerror.Wrap(err, "扣费事务中，查询当前基本余额和额外余额失败")
 		}
-		remaining_quota := old_quota.RemainingQuota
-		extra_quota := old_quota.ExtraQuota
+		if qp == nil {
+			return gerror.New("没有找到这个配额池。请重新检查")
+		}
+
+		// 持锁后再按幂等键检查一次，避免并发重放重复计费。
+		// 配额池的锁只能串行化同一配额池的请求，不同配额池的请求复用同一个幂等键时由唯一约束兜底
+		if req.RequestId != "" {
+			if replayed, err := findBillingRecordByRequestId(ctx, req, res); err != nil {
+				return err
+			} else if replayed {
+				return nil
+			}
+		}
+
+		if qp.Disabled {
+			return gerror.New("这个配额池被禁用了，不能使用")
+		}
+		var plan string
+		if qp.Personal {
+			plan = "Included"
+		} else {
+			plan = "Quota Pool"
+		}
+
+		data := g.Map{
+			"upn":           req.Upn,
+			"svc":           req.Service,
+			"product":       req.Product,
+			"original_cost": originalCost,
+			"cost":          cost,
+			"plan":          plan,
+			"source":        req.Source,
+			"remark":        req.Remark,
+		}
+		if req.RequestId != "" {
+			data["request_id"] = req.RequestId
+		}
+		recordId, err := dao.BillingCostRecords.Ctx(ctx).Data(data).InsertAndGetId()
+		if err != nil {
+			if isUniqueViolation(err) {
+				return gerror.Newf("幂等键 %v 已经用于参数不同的计费请求", req.RequestId)
+			}
+			return gerror.Wrapf(err, "计费记录写入失败。终止扣费流程，没有扣费。计费信息：%v", req)
+		}
+		res.RecordId = recordId
+		res.Cost = cost
+		// 同步累加每日用量汇总
+		if err = usageRollup.Accumulate(ctx, recordId); err != nil {
+			return err
+		}
+
+		// 扣钱流程
+		remaining_quota := qp.RemainingQuota
+		extra_quota := qp.ExtraQuota
 		// 1. 优先扣除基本余额的正值部分
 		if remaining_quota.IsPositive() {
 			deduction := decimal.Min(remaining_quota, cost)
 			remaining_quota = remaining_quota.Sub(deduction)
 			cost = cost.Sub(deduction)
 		}
-		// 2. 如果还有剩余费用，则从额外余额中扣除
-		if cost.IsPositive() {
+		// 2. 如果还有剩余费用，则从额外余额中扣除，优先扣除最早过期的加油包
+		if cost.IsPositive() && extra_quota.IsPositive() {
 			deduction := decimal.Min(extra_quota, cost)
+			if _, err = quotaPool.ConsumeExtraGrants(ctx, req.Source, deduction); err != nil {
+				return gerror.Wrap(err, "扣费事务中，扣减加油包失败")
+			}
 			extra_quota = extra_quota.Sub(deduction)
 			cost = cost.Sub(deduction)
 		}
@@ -159,10 +219,54 @@ func (c *ControllerV1) BillingRecord(ctx context.Context, req *v1.BillingRecordR
 		if err != nil {
 			return gerror.Wrap(err, "扣费事务中，更新扣费后的基本余额和额外余额失败")
 		}
+		if err = quotaPool.WriteLedger(ctx, &quotaPool.LedgerEntry{
+			QuotaPoolName:   req.Source,
+			Reason:          quotaPool.LedgerCharge,
+			Actor:           req.Upn,
+			RemainingBefore: qp.RemainingQuota,
+			RemainingAfter:  remaining_quota,
+			ExtraBefore:     qp.ExtraQuota,
+			ExtraAfter:      extra_quota,
+			BillingRecordId: recordId,
+		}); err != nil {
+			return gerror.Wrap(err, "扣费事务中，写入配额池账本失败")
+		}
 
 		return nil
 	})
+	if err != nil {
+		return
+	}
 
 	res.Ok = true
 	return
 }
+
+// findBillingRecordByRequestId 根据幂等键查找已经写入的计费记录。找到时将首次计费的结果填入 res，并返回 true。
+// 找到的记录与本次请求的 upn、service、product 或 source 不同时返回错误，不当作重放。
+func findBillingRecordByRequestId(ctx context.Context, req *v1.BillingRecordReq, res *v1.BillingRecordRes) (found bool, err error) {
+	var record *entity.BillingCostRecords
+	if err = dao.BillingCostRecords.Ctx(ctx).
+		Fields("id, upn, svc, product, source, cost").
+		Where("request_id = ?", req.RequestId).
+		Scan(&record); err != nil {
+		return false, gerror.Wrapf(err, "根据幂等键查询计费记录失败。幂等键：%v", req.RequestId)
+	}
+	if record == nil {
+		return false, nil
+	}
+	if record.Upn != req.Upn || record.Svc != req.Service || record.Product != req.Product || record.Source != req.Source {
+		return false, gerror.Newf("幂等键 %v 已经用于参数不同的计费请求（首次计费记录 %v）", req.RequestId, record.Id)
+	}
+	res.Ok = true
+	res.RecordId = record.Id
+	res.Cost = record.Cost
+	res.Replayed = true
+	return true, nil
+}
+
+// isUniqueViolation 判断是否违反了唯一约束（SQLSTATE 23505）。计费记录表中只有幂等键有唯一约束。
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
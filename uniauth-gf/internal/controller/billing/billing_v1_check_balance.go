@@ -15,6 +15,18 @@ func (c *ControllerV1) CheckBalance(ctx context.Context, req *v1.CheckBalanceReq
 	if err != nil {
 		return nil, gerror.Wrap(err, "检查余额事务中发生错误")
 	}
-	res.Ok = balance.IsPositive()
+	// 扣除进行中的预留冻结的额度
+	held, err := quotaPool.HeldAmount(ctx, req.QuotaPool)
+	if err != nil {
+		return nil, gerror.Wrap(err, "检查余额时查询冻结额度失败")
+	}
+	res.Ok = balance.Sub(held).IsPositive()
+	if !res.Ok || req.Upn == "" {
+		return
+	}
+	// 共享配额池内的个人限额
+	if res.Ok, _, err = quotaPool.CheckUserAllowance(ctx, req.QuotaPool, req.Upn); err != nil {
+		return nil, gerror.Wrap(err, "检查余额时查询个人限额失败")
+	}
 	return
 }
@@ -17,9 +17,9 @@ func (c *ControllerV1) CheckTokensUsage(ctx context.Context, req *v1.CheckTokens
 	res = &v1.CheckTokensUsageRes{}
 
 	startDate := gtime.Now().AddDate(0, 0, 1-req.NDays).Format("Y-m-d")
-	result, err := dao.BillingCostRecords.Ctx(ctx).
-		Where("upn = ? AND source = ? AND created_at >= ?", req.Upn, req.QuotaPool, startDate).
-		Fields("DATE(created_at) as date, product, SUM(cost) as total_cost").
+	result, err := dao.BillingUsageDaily.Ctx(ctx).
+		Where("upn = ? AND source = ? AND date >= ?", req.Upn, req.QuotaPool, startDate).
+		Fields("date, product, SUM(cost) as total_cost").
 		Group("date, product").
 		Order("date desc").
 		All()
@@ -0,0 +1,70 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	v1 "uniauth-gf/api/billing/v1"
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/quotaPool"
+)
+
+// CommitReservation 按实际费用提交预留。
+//
+// 计费直接复用 BillingRecord，并以预留 ID 作为幂等键，所以重复提交不会重复扣费。
+// 计费和预留状态的更新在同一个事务中完成。预留提交后不再计入冻结额度，未使用的部分自然释放。
+func (c *ControllerV1) CommitReservation(ctx context.Context, req *v1.CommitReservationReq) (res *v1.CommitReservationRes, err error) {
+	res = &v1.CommitReservationRes{}
+	err = dao.QuotapoolReservation.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		reservation, err := quotaPool.GetReservationForUpdate(ctx, req.ReservationId)
+		if err != nil {
+			return err
+		}
+		switch reservation.Status {
+		case quotaPool.ReservationCommitted:
+			res.RecordId = reservation.BillingRecordId
+			res.Cost = reservation.ActualCost
+			return nil
+		case quotaPool.ReservationReleased:
+			return gerror.New("预留已经释放，不能提交")
+		}
+
+		// 过期的预留仍然允许提交：对话已经实际发生，费用需要如实记录
+		remark := req.Remark
+		if remark == nil {
+			remark = gjson.New(g.Map{})
+		}
+		if wrtErr := remark.Set("reservation_id", reservation.ReservationId); wrtErr != nil {
+			g.Log().Infof(ctx, "提交预留时预留 ID 写入 Remark 失败。预留 ID：%v", reservation.ReservationId)
+		}
+		recordRes, err := c.BillingRecord(ctx, &v1.BillingRecordReq{
+			Upn:       reservation.Upn,
+			Service:   reservation.Svc,
+			Product:   reservation.Product,
+			Source:    reservation.QuotaPoolName,
+			CNYCost:   req.CNYCost,
+			USDCost:   req.USDCost,
+			Costs:     req.Costs,
+			Remark:    remark,
+			RequestId: "reservation:" + reservation.ReservationId,
+		})
+		if err != nil {
+			return err
+		}
+		if err = quotaPool.MarkReservationCommitted(ctx, reservation.ReservationId, recordRes.Cost, recordRes.RecordId); err != nil {
+			return err
+		}
+		res.RecordId = recordRes.RecordId
+		res.Cost = recordRes.Cost
+		return nil
+	})
+	if err != nil {
+		return nil, gerror.Wrapf(err, "提交预留 %v 失败", req.ReservationId)
+	}
+	res.Ok = true
+	return
+}
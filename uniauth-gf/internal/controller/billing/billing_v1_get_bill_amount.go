@@ -9,6 +9,7 @@ import (
 
 	v1 "uniauth-gf/api/billing/v1"
 	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/quotaPool"
 )
 
 func (c *ControllerV1) GetBillAmount(ctx context.Context, req *v1.GetBillAmountReq) (res *v1.GetBillAmountRes, err error) {
@@ -32,24 +33,49 @@ func (c *ControllerV1) GetBillAmount(ctx context.Context, req *v1.GetBillAmountR
 		return nil, gerror.Wrapf(err, "%s 不能传空", keyField)
 	}
 
-	result, err = dao.BillingCostRecords.Ctx(ctx).
-		OmitEmpty().
-		WhereIn(keyField, keyValues).
-		WhereIn("svc", req.Svc).
-		WhereIn("product", req.Product).
-		WhereGTE("created_at", req.StartTime).
-		WhereLTE("created_at", req.EndTime).
-		Fields("SUM(cost) as cost, SUM(original_cost) as original_cost").
-		One()
-
-	if err != nil {
-		return nil, gerror.Wrapf(err, "[%s 模式] 获取 %s = %s 账单总金额失败", req.Type, keyField, keyValues)
+	// 完整覆盖的日期读取每日用量汇总，首尾不足一天的部分仍然读取原始计费记录
+	fullFrom := req.StartTime.StartOfDay()
+	if !fullFrom.Equal(req.StartTime) {
+		fullFrom = fullFrom.AddDate(0, 0, 1)
+	}
+	fullTo := req.EndTime.StartOfDay()
+	var parts []*gdb.Model
+	if fullFrom.Before(fullTo) {
+		parts = append(parts,
+			dao.BillingUsageDaily.Ctx(ctx).
+				WhereGTE("date", fullFrom.Format("Y-m-d")).
+				WhereLT("date", fullTo.Format("Y-m-d")),
+			dao.BillingCostRecords.Ctx(ctx).
+				WhereGTE("created_at", req.StartTime).
+				WhereLT("created_at", fullFrom),
+			dao.BillingCostRecords.Ctx(ctx).
+				WhereGTE("created_at", fullTo).
+				WhereLTE("created_at", req.EndTime),
+		)
+	} else {
+		parts = append(parts, dao.BillingCostRecords.Ctx(ctx).
+			WhereGTE("created_at", req.StartTime).
+			WhereLTE("created_at", req.EndTime),
+		)
 	}
 
-	costStr := result["cost"].String()
-	originalCostStr := result["original_cost"].String()
-	cost, _ = decimal.NewFromString(costStr)
-	originalCost, _ = decimal.NewFromString(originalCostStr)
+	for _, part := range parts {
+		// 只统计授权域中配额池的账单
+		result, err = quotaPool.ScopeAdminDomainSource(ctx, part, "source").
+			OmitEmpty().
+			WhereIn(keyField, keyValues).
+			WhereIn("svc", req.Svc).
+			WhereIn("product", req.Product).
+			Fields("COALESCE(SUM(cost), 0) as cost, COALESCE(SUM(original_cost), 0) as original_cost").
+			One()
+		if err != nil {
+			return nil, gerror.Wrapf(err, "[%s 模式] 获取 %s = %s 账单总金额失败", req.Type, keyField, keyValues)
+		}
+		partCost, _ := decimal.NewFromString(result["cost"].String())
+		partOriginalCost, _ := decimal.NewFromString(result["original_cost"].String())
+		cost = cost.Add(partCost)
+		originalCost = originalCost.Add(partOriginalCost)
+	}
 
 	res = &v1.GetBillAmountRes{
 		Amount:         cost,
@@ -5,6 +5,7 @@ import (
 
 	v1 "uniauth-gf/api/billing/v1"
 	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/quotaPool"
 
 	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/encoding/gjson"
@@ -44,6 +45,8 @@ func (c *ControllerV1) GetBillRecord(ctx context.Context, req *v1.GetBillRecordR
 		WhereIn("product", req.Product).
 		WhereGTE("created_at", req.StartTime).
 		WhereLTE("created_at", req.EndTime)
+	// 只返回授权域中配额池的账单，导出账单复用这里的查询
+	model = quotaPool.ScopeAdminDomainSource(ctx, model, "source")
 
 	if req.Keywords != "" {
 		model = model.WhereLike(searchField, "%"+req.Keywords+"%")
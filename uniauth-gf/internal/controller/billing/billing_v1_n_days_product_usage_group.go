@@ -2,7 +2,6 @@ package billing
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -12,11 +11,11 @@ import (
 )
 
 func (c *ControllerV1) NDaysProductUsageGroup(ctx context.Context, req *v1.NDaysProductUsageGroupReq) (res *v1.NDaysProductUsageGroupRes, err error) {
-	result, err := dao.BillingCostRecords.Ctx(ctx).
+	result, err := dao.BillingUsageDaily.Ctx(ctx).
 		Where("svc = ?", "chat").
-		Where(fmt.Sprintf("created_at >= NOW() - INTERVAL '%d' DAY", req.N)).
+		Where("date >= CURRENT_DATE - ?::integer", req.N).
 		Group("product").
-		Fields("product, COUNT(*) as count").
+		Fields("product, SUM(count) as count").
 		All()
 	if err != nil {
 		return nil, gerror.Wrap(err, "账单筛选数据失败 (Group.Group)")
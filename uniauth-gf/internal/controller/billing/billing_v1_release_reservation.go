@@ -0,0 +1,17 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/billing/v1"
+	"uniauth-gf/internal/service/quotaPool"
+)
+
+func (c *ControllerV1) ReleaseReservation(ctx context.Context, req *v1.ReleaseReservationReq) (res *v1.ReleaseReservationRes, err error) {
+	if err = quotaPool.Release(ctx, req.ReservationId); err != nil {
+		return nil, gerror.Wrap(err, "释放预留失败")
+	}
+	return &v1.ReleaseReservationRes{Ok: true}, nil
+}
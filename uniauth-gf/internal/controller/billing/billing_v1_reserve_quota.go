@@ -0,0 +1,29 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/billing/v1"
+	"uniauth-gf/internal/service/quotaPool"
+)
+
+func (c *ControllerV1) ReserveQuota(ctx context.Context, req *v1.ReserveQuotaReq) (res *v1.ReserveQuotaRes, err error) {
+	reservation, err := quotaPool.Reserve(ctx, &quotaPool.ReserveInfo{
+		QuotaPoolName: req.Source,
+		Upn:           req.Upn,
+		Svc:           req.Service,
+		Product:       req.Product,
+		Amount:        req.Amount,
+		Ttl:           req.Ttl,
+	})
+	if err != nil {
+		return nil, gerror.Wrap(err, "预留额度失败")
+	}
+	return &v1.ReserveQuotaRes{
+		Ok:            true,
+		ReservationId: reservation.ReservationId,
+		ExpiresAt:     reservation.ExpiresAt,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/robfig/cron/v3"
+	"github.com/shopspring/decimal"
 
 	v1 "uniauth-gf/api/config/v1"
 	"uniauth-gf/internal/dao"
@@ -18,6 +19,24 @@ func (c *ControllerV1) AddAutoQuotaPoolConfig(ctx context.Context, req *v1.AddAu
 		err = gerror.Newf("cronCycle 无效: %v", cronErr)
 		return
 	}
+	if req.UserLimitCronCycle != "" {
+		if _, cronErr := cron.ParseStandard(req.UserLimitCronCycle); cronErr != nil {
+			err = gerror.Newf("userLimitCronCycle 无效: %v", cronErr)
+			return
+		}
+	}
+
+	// 不传个人限额和结转上限时不限制
+	noLimit := decimal.NewFromInt(-1)
+	if req.UserQuotaLimit == nil {
+		req.UserQuotaLimit = &noLimit
+	}
+	if req.RolloverCapPercent == nil {
+		req.RolloverCapPercent = &noLimit
+	}
+	if req.RolloverCapAmount == nil {
+		req.RolloverCapAmount = &noLimit
+	}
 
 	// 使用事务，确保后续一致性（如后续 casbin/同步失败，则回滚新增）
 	if err = dao.ConfigAutoQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/config/v1"
+	"uniauth-gf/internal/service/exchangeRate"
+)
+
+func (c *ControllerV1) ConvertCurrency(ctx context.Context, req *v1.ConvertCurrencyReq) (res *v1.ConvertCurrencyRes, err error) {
+	to := strings.ToUpper(req.To)
+	if to == "" {
+		to = exchangeRate.BaseCurrency(ctx)
+	}
+	rate, rateDate, err := exchangeRate.GetRateAt(ctx, req.From, to, req.Date)
+	if err != nil {
+		return nil, gerror.Wrap(err, "获取汇率失败")
+	}
+	return &v1.ConvertCurrencyRes{
+		Amount:   req.Amount.Mul(rate),
+		To:       to,
+		Rate:     rate,
+		RateDate: rateDate,
+	}, nil
+}
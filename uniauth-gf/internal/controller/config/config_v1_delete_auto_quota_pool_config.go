@@ -34,13 +34,12 @@ func (c *ControllerV1) DeleteAutoQuotaPoolConfig(ctx context.Context, req *v1.De
 
 		// 删除Casbin规则
 		casbin_subject := "auto_qp_" + req.RuleName
-		e := casbin.GetEnforcer()
-		if _, err := e.RemoveFilteredPolicy(0, casbin_subject); err != nil {
+		if _, err := casbin.RemoveFilteredPolicy(ctx, "config.DeleteAutoQuotaPoolConfig", 0, casbin_subject); err != nil {
 			return gerror.Wrap(err, "删除自动配额池规则的现有 Casbin 策略失败")
 		}
 
 		// 删除Casbin分组策略
-		if _, err := e.RemoveFilteredGroupingPolicy(1, casbin_subject); err != nil {
+		if _, err := casbin.RemoveFilteredGroupingPolicy(ctx, "config.DeleteAutoQuotaPoolConfig", 1, casbin_subject); err != nil {
 			return gerror.Wrap(err, "删除自动配额池规则的现有 Casbin 分组策略失败")
 		}
 		return nil
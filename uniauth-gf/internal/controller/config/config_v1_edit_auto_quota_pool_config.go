@@ -23,6 +23,12 @@ func (c *ControllerV1) EditAutoQuotaPoolConfig(ctx context.Context, req *v1.Edit
 		err = gerror.Newf("cronCycle 无效: %v", cronErr)
 		return
 	}
+	if req.UserLimitCronCycle != "" {
+		if _, cronErr := cron.ParseStandard(req.UserLimitCronCycle); cronErr != nil {
+			err = gerror.Newf("userLimitCronCycle 无效: %v", cronErr)
+			return
+		}
+	}
 
 	err = dao.ConfigAutoQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
 		var configAutoQuotaPool *entity.ConfigAutoQuotaPool
@@ -35,11 +41,23 @@ func (c *ControllerV1) EditAutoQuotaPoolConfig(ctx context.Context, req *v1.Edit
 		}
 
 		data := g.Map{
-			"cron_cycle":    req.CronCycle,
-			"regular_quota": req.RegularQuota,
-			"enabled":       req.Enabled,
-			"description":   req.Description,
-			"priority":      req.Priority,
+			"cron_cycle":            req.CronCycle,
+			"regular_quota":         req.RegularQuota,
+			"enabled":               req.Enabled,
+			"description":           req.Description,
+			"priority":              req.Priority,
+			"user_limit_cron_cycle": req.UserLimitCronCycle,
+			"rollover_policy":       req.RolloverPolicy,
+		}
+		// 个人限额和结转上限不传时不修改
+		if req.UserQuotaLimit != nil {
+			data["user_quota_limit"] = *req.UserQuotaLimit
+		}
+		if req.RolloverCapPercent != nil {
+			data["rollover_cap_percent"] = *req.RolloverCapPercent
+		}
+		if req.RolloverCapAmount != nil {
+			data["rollover_cap_amount"] = *req.RolloverCapAmount
 		}
 		// 仅当字段在请求中出现时才处理；显式 null 或空对象 {} 则置为数据库 NULL
 		if req.FilterGroup != nil {
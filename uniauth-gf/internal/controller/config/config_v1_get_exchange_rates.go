@@ -0,0 +1,37 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/config/v1"
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+func (c *ControllerV1) GetExchangeRates(ctx context.Context, req *v1.GetExchangeRatesReq) (res *v1.GetExchangeRatesRes, err error) {
+	res = &v1.GetExchangeRatesRes{
+		Items: []entity.ConfigExchangeRate{},
+	}
+	model := dao.ConfigExchangeRate.Ctx(ctx).
+		OmitEmpty().
+		Where("f", strings.ToUpper(req.F)).
+		Where("t", strings.ToUpper(req.T))
+	if req.StartDate != nil {
+		model = model.WhereGTE("date", req.StartDate.Format("Y-m-d"))
+	}
+	if req.EndDate != nil {
+		model = model.WhereLTE("date", req.EndDate.Format("Y-m-d"))
+	}
+	if err = model.
+		OrderDesc("date").
+		OrderAsc("f").
+		OrderAsc("t").
+		Limit(req.Limit).
+		Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询汇率失败")
+	}
+	return
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	v1 "uniauth-gf/api/config/v1"
+	"uniauth-gf/internal/model/entity"
+	"uniauth-gf/internal/service/exchangeRate"
+)
+
+func (c *ControllerV1) UploadExchangeRates(ctx context.Context, req *v1.UploadExchangeRatesReq) (res *v1.UploadExchangeRatesRes, err error) {
+	rates := make([]*entity.ConfigExchangeRate, 0, len(req.Rates))
+	for _, item := range req.Rates {
+		rates = append(rates, &entity.ConfigExchangeRate{
+			Date: item.Date,
+			F:    item.F,
+			T:    item.T,
+			Rate: item.Rate,
+		})
+	}
+	if err = exchangeRate.UpsertRates(ctx, rates); err != nil {
+		return nil, gerror.Wrap(err, "上传汇率失败")
+	}
+	return &v1.UploadExchangeRatesRes{
+		OK:    true,
+		Count: len(rates),
+	}, nil
+}
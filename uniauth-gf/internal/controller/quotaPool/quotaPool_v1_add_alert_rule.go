@@ -0,0 +1,44 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/grand"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) AddAlertRule(ctx context.Context, req *v1.AddAlertRuleReq) (res *v1.AddAlertRuleRes, err error) {
+	if req.Threshold.IsNegative() {
+		return nil, gerror.New("阈值不能小于 0")
+	}
+	count, err := dao.QuotapoolQuotaPool.Ctx(ctx).Where("quota_pool_name = ?", req.QuotaPoolName).Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询配额池失败")
+	}
+	if count == 0 {
+		return nil, gerror.Newf("该配额池不存在，请重新检查：%v", req.QuotaPoolName)
+	}
+	secret := req.Secret
+	if secret == "" {
+		secret = grand.S(32)
+	}
+	id, err := dao.QuotapoolAlertRule.Ctx(ctx).Data(g.Map{
+		"quota_pool_name": req.QuotaPoolName,
+		"threshold_type":  req.ThresholdType,
+		"threshold":       req.Threshold,
+		"webhook_url":     req.WebhookUrl,
+		"secret":          secret,
+		"enabled":         req.Enabled,
+	}).InsertAndGetId()
+	if err != nil {
+		return nil, gerror.Wrap(err, "新建告警规则失败")
+	}
+	return &v1.AddAlertRuleRes{
+		Id:     id,
+		Secret: secret,
+	}, nil
+}
@@ -29,8 +29,8 @@ func (c *ControllerV1) BatchModifyQuotaPool(ctx context.Context, req *v1.BatchMo
 		return
 	}
 
-	// 创建查询模型并应用过滤条件
-	model := dao.QuotapoolQuotaPool.Ctx(ctx)
+	// 创建查询模型并应用过滤条件，只能修改授权域中的配额池
+	model := quotaPoolService.ScopeAdminDomain(ctx, dao.QuotapoolQuotaPool.Ctx(ctx))
 	model, err = quotaPoolService.ApplyQuotaPoolFilter(ctx, model, req.Filter)
 	if err != nil {
 		res.Err = gerror.Wrap(err, "应用过滤条件失败").Error()
@@ -69,7 +69,7 @@ func (c *ControllerV1) BatchModifyQuotaPool(ctx context.Context, req *v1.BatchMo
 			}
 
 			// 重新应用过滤条件并执行更新
-			updateModel := dao.QuotapoolQuotaPool.Ctx(ctx)
+			updateModel := quotaPoolService.ScopeAdminDomain(ctx, dao.QuotapoolQuotaPool.Ctx(ctx))
 			updateModel, filterErr := quotaPoolService.ApplyQuotaPoolFilter(ctx, updateModel, req.Filter)
 			if filterErr != nil {
 				return gerror.Wrap(filterErr, "重新应用过滤条件失败")
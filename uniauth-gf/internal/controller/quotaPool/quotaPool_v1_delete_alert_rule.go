@@ -0,0 +1,21 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) DeleteAlertRule(ctx context.Context, req *v1.DeleteAlertRuleReq) (res *v1.DeleteAlertRuleRes, err error) {
+	result, err := dao.QuotapoolAlertRule.Ctx(ctx).WherePri(req.Id).Delete()
+	if err != nil {
+		return nil, gerror.Wrap(err, "删除告警规则失败")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.Newf("告警规则不存在，请重新检查：%v", req.Id)
+	}
+	return &v1.DeleteAlertRuleRes{OK: true}, nil
+}
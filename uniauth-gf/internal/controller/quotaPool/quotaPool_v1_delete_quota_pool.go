@@ -11,6 +11,9 @@ import (
 
 func (c *ControllerV1) DeleteQuotaPool(ctx context.Context, req *v1.DeleteQuotaPoolReq) (res *v1.DeleteQuotaPoolRes, err error) {
 	res = &v1.DeleteQuotaPoolRes{}
+	if err = quotaPool.CheckAdminDomain(ctx, req.QuotaPoolName); err != nil {
+		return nil, err
+	}
 	if err := quotaPool.Delete(ctx, req.QuotaPoolName); err != nil {
 		return nil, gerror.Wrap(err, "删除配额池失败")
 	}
@@ -0,0 +1,54 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) EditAlertRule(ctx context.Context, req *v1.EditAlertRuleReq) (res *v1.EditAlertRuleRes, err error) {
+	data := g.Map{}
+	if req.ThresholdType != nil {
+		data["threshold_type"] = *req.ThresholdType
+	}
+	if req.Threshold != nil {
+		if req.Threshold.IsNegative() {
+			return nil, gerror.New("阈值不能小于 0")
+		}
+		data["threshold"] = *req.Threshold
+	}
+	if req.WebhookUrl != nil {
+		data["webhook_url"] = *req.WebhookUrl
+	}
+	if req.Secret != nil {
+		if *req.Secret == "" {
+			return nil, gerror.New("签名密钥不能为空")
+		}
+		data["secret"] = *req.Secret
+	}
+	if req.Enabled != nil {
+		data["enabled"] = *req.Enabled
+	}
+	if len(data) == 0 {
+		return &v1.EditAlertRuleRes{OK: true}, nil
+	}
+	if req.ThresholdType != nil || req.Threshold != nil {
+		// 阈值变了，之前的触发状态不再有意义，下一次余额变动时重新评估
+		data["triggered"] = false
+	}
+	data["updated_at"] = gtime.Now()
+
+	result, err := dao.QuotapoolAlertRule.Ctx(ctx).WherePri(req.Id).Data(data).Update()
+	if err != nil {
+		return nil, gerror.Wrap(err, "编辑告警规则失败")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.Newf("告警规则不存在，请重新检查：%v", req.Id)
+	}
+	return &v1.EditAlertRuleRes{OK: true}, nil
+}
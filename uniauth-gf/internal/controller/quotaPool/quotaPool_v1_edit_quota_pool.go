@@ -12,6 +12,9 @@ import (
 
 func (c *ControllerV1) EditQuotaPool(ctx context.Context, req *v1.EditQuotaPoolReq) (res *v1.EditQuotaPoolRes, err error) {
 	res = &v1.EditQuotaPoolRes{}
+	if err = quotaPool.CheckAdminDomain(ctx, req.QuotaPoolName); err != nil {
+		return nil, err
+	}
 
 	// 手动构建 map，只包含非 nil 的字段
 	qp := g.Map{
@@ -36,6 +39,21 @@ func (c *ControllerV1) EditQuotaPool(ctx context.Context, req *v1.EditQuotaPoolR
 	if req.UserinfosRules != nil {
 		qp["userinfosRules"] = req.UserinfosRules
 	}
+	if req.UserQuotaLimit != nil {
+		qp["userQuotaLimit"] = *req.UserQuotaLimit
+	}
+	if req.UserLimitCronCycle != nil {
+		qp["userLimitCronCycle"] = *req.UserLimitCronCycle
+	}
+	if req.RolloverPolicy != nil {
+		qp["rolloverPolicy"] = *req.RolloverPolicy
+	}
+	if req.RolloverCapPercent != nil {
+		qp["rolloverCapPercent"] = *req.RolloverCapPercent
+	}
+	if req.RolloverCapAmount != nil {
+		qp["rolloverCapAmount"] = *req.RolloverCapAmount
+	}
 
 	if err = quotaPool.Edit(ctx, qp); err != nil {
 		return nil, gerror.Wrap(err, "更新配额池失败")
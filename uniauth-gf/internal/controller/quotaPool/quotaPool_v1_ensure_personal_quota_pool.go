@@ -55,6 +55,11 @@ func (c *ControllerV1) EnsurePersonalQuotaPool(ctx context.Context, req *v1.Ensu
 					"value": req.Upn,
 				}},
 			}),
+			UserQuotaLimit:     autoQPConfig.UserQuotaLimit,
+			UserLimitCronCycle: autoQPConfig.UserLimitCronCycle,
+			RolloverPolicy:     autoQPConfig.RolloverPolicy,
+			RolloverCapPercent: autoQPConfig.RolloverCapPercent,
+			RolloverCapAmount:  autoQPConfig.RolloverCapAmount,
 		}
 		if err = quotaPool.Create(ctx, data); err != nil {
 			return gerror.Wrap(err, "新建个人配额池时发生内部错误")
@@ -62,8 +67,7 @@ func (c *ControllerV1) EnsurePersonalQuotaPool(ctx context.Context, req *v1.Ensu
 
 		// 由于只有个人配额池会有 g, 个人配额池, 自动配额池的情况
 		// 因此在 Ensure 函数里面写添加角色继承规则的逻辑
-		e := casbin.GetEnforcer()
-		if _, err = e.AddGroupingPolicy(data.QuotaPoolName, "auto_qp_"+ autoQPConfig.RuleName); err != nil {
+		if _, err = casbin.AddGroupingPolicies(ctx, "quotaPool.EnsurePersonalQuotaPool", [][]string{{data.QuotaPoolName, "auto_qp_" + autoQPConfig.RuleName, casbin.DefaultDomain}}, true); err != nil {
 			return gerror.Wrap(err, "添加 g, 个人配额池, 自动配额池 角色继承规则时发生内部错误")
 		}
 		return nil
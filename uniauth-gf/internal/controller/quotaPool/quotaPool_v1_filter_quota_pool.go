@@ -22,8 +22,8 @@ func (c *ControllerV1) FilterQuotaPool(ctx context.Context, req *v1.FilterQuotaP
 		}
 	}
 
-	// 创建查询模型
-	model := dao.QuotapoolQuotaPool.Ctx(ctx)
+	// 创建查询模型，只返回授权域中的配额池
+	model := quotaPoolService.ScopeAdminDomain(ctx, dao.QuotapoolQuotaPool.Ctx(ctx))
 
 	// 应用过滤条件
 	model, err = quotaPoolService.ApplyQuotaPoolFilter(ctx, model, req.Filter)
@@ -0,0 +1,38 @@
+package quotaPool
+
+import (
+	"context"
+	"math"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) GetAlertDeliveries(ctx context.Context, req *v1.GetAlertDeliveriesReq) (res *v1.GetAlertDeliveriesRes, err error) {
+	model := dao.QuotapoolAlertDelivery.Ctx(ctx).
+		OmitEmpty().
+		Where("quota_pool_name", req.QuotaPoolName).
+		Where("rule_id", req.RuleId).
+		Where("status", req.Status)
+
+	total, err := model.Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询告警投递记录总数失败")
+	}
+
+	res = &v1.GetAlertDeliveriesRes{
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+	}
+	if err = model.
+		OrderDesc("id").
+		Page(req.Page, req.PageSize).
+		Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询告警投递记录失败")
+	}
+	return
+}
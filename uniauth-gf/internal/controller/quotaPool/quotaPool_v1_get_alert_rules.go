@@ -0,0 +1,23 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) GetAlertRules(ctx context.Context, req *v1.GetAlertRulesReq) (res *v1.GetAlertRulesRes, err error) {
+	res = &v1.GetAlertRulesRes{}
+	if err = dao.QuotapoolAlertRule.Ctx(ctx).
+		FieldsEx("secret").
+		OmitEmpty().
+		Where("quota_pool_name", req.QuotaPoolName).
+		OrderAsc("id").
+		Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询告警规则失败")
+	}
+	return
+}
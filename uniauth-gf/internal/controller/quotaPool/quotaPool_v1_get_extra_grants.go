@@ -0,0 +1,23 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/quotaPool"
+)
+
+func (c *ControllerV1) GetExtraGrants(ctx context.Context, req *v1.GetExtraGrantsReq) (res *v1.GetExtraGrantsRes, err error) {
+	res = &v1.GetExtraGrantsRes{}
+	model := dao.QuotapoolExtraGrant.Ctx(ctx).Where("quota_pool_name = ?", req.QuotaPoolName)
+	if !req.IncludeInactive {
+		model = model.Where("status = ?", quotaPool.GrantActive)
+	}
+	if err = model.Order("expires_at ASC NULLS LAST, id ASC").Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询配额池的加油包失败")
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package quotaPool
+
+import (
+	"context"
+	"math"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+)
+
+func (c *ControllerV1) GetQuotaPoolLedger(ctx context.Context, req *v1.GetQuotaPoolLedgerReq) (res *v1.GetQuotaPoolLedgerRes, err error) {
+	model := dao.QuotapoolLedger.Ctx(ctx).
+		Where("quota_pool_name = ?", req.QuotaPoolName).
+		OmitEmpty().
+		Where("reason", req.Reason).
+		WhereGTE("created_at", req.StartTime).
+		WhereLT("created_at", req.EndTime)
+
+	total, err := model.Count()
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询配额池账本总数失败")
+	}
+
+	res = &v1.GetQuotaPoolLedgerRes{
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.PageSize))),
+	}
+	if err = model.
+		OrderDesc("id").
+		Page(req.Page, req.PageSize).
+		Scan(&res.Items); err != nil {
+		return nil, gerror.Wrap(err, "查询配额池账本失败")
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/service/casbin"
+	"uniauth-gf/internal/service/quotaPool"
+)
+
+func (c *ControllerV1) GetUserAllowance(ctx context.Context, req *v1.GetUserAllowanceReq) (res *v1.GetUserAllowanceRes, err error) {
+	upns := req.Upns
+	if len(upns) == 0 {
+		domain, err := quotaPool.GetDomain(ctx, req.QuotaPoolName)
+		if err != nil {
+			return nil, err
+		}
+		if upns, err = casbin.GetEnforcer().GetUsersForRole(req.QuotaPoolName, domain); err != nil {
+			return nil, gerror.Wrap(err, "查询配额池用户失败")
+		}
+	}
+	allowances, err := quotaPool.GetUserAllowances(ctx, req.QuotaPoolName, upns)
+	if err != nil {
+		return nil, gerror.Wrap(err, "查询个人限额使用情况失败")
+	}
+	res = &v1.GetUserAllowanceRes{
+		Items: make([]*v1.UserAllowanceItem, 0, len(allowances)),
+	}
+	for _, allowance := range allowances {
+		res.Items = append(res.Items, &v1.UserAllowanceItem{
+			Upn:         allowance.Upn,
+			Limited:     allowance.Limited,
+			Limit:       allowance.Limit,
+			Used:        allowance.Used,
+			Held:        allowance.Held,
+			Remaining:   allowance.Remaining,
+			PeriodStart: allowance.PeriodStart,
+		})
+	}
+	return
+}
@@ -3,25 +3,59 @@ package quotaPool
 import (
 	"context"
 
+	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/shopspring/decimal"
 
 	v1 "uniauth-gf/api/quotaPool/v1"
 	"uniauth-gf/internal/model/entity"
+	"uniauth-gf/internal/service/operator"
 	"uniauth-gf/internal/service/quotaPool"
 )
 
 func (c *ControllerV1) NewQuotaPool(ctx context.Context, req *v1.NewQuotaPoolReq) (res *v1.NewQuotaPoolRes, err error) {
+	// 管理员只能在授权的域中新建配额池
+	if dom, ok := operator.AdminDomain(ctx); ok {
+		if req.Domain == "" {
+			req.Domain = dom
+		} else if req.Domain != dom {
+			return nil, gerror.NewCodef(gcode.CodeNotAuthorized, "配额池所属的域 %v 与授权的域 %v 不一致", req.Domain, dom)
+		}
+	}
+	// 不传个人限额时不限制
+	userQuotaLimit := decimal.NewFromInt(-1)
+	if req.UserQuotaLimit != nil {
+		userQuotaLimit = *req.UserQuotaLimit
+	}
+	// 不传结转上限时不封顶
+	rolloverCapPercent, rolloverCapAmount := decimal.NewFromInt(-1), decimal.NewFromInt(-1)
+	if req.RolloverCapPercent != nil {
+		rolloverCapPercent = *req.RolloverCapPercent
+	}
+	if req.RolloverCapAmount != nil {
+		rolloverCapAmount = *req.RolloverCapAmount
+	}
+	rolloverPolicy := req.RolloverPolicy
+	if rolloverPolicy == "" {
+		rolloverPolicy = quotaPool.RolloverDiscard
+	}
 	data := &entity.QuotapoolQuotaPool{
-		QuotaPoolName:  req.QuotaPoolName,
-		CronCycle:      req.CronCycle,
-		RegularQuota:   req.RegularQuota,
-		RemainingQuota: req.RegularQuota, // 需要初始化剩余配额为定期配额
-		LastResetAt:    gtime.Now(),
-		ExtraQuota:     req.ExtraQuota,
-		Personal:       req.Personal,
-		Disabled:       req.Disabled,
-		UserinfosRules: req.UserinfosRules,
+		QuotaPoolName:      req.QuotaPoolName,
+		CronCycle:          req.CronCycle,
+		RegularQuota:       req.RegularQuota,
+		RemainingQuota:     req.RegularQuota, // 需要初始化剩余配额为定期配额
+		LastResetAt:        gtime.Now(),
+		ExtraQuota:         req.ExtraQuota,
+		Personal:           req.Personal,
+		Disabled:           req.Disabled,
+		UserinfosRules:     req.UserinfosRules,
+		UserQuotaLimit:     userQuotaLimit,
+		UserLimitCronCycle: req.UserLimitCronCycle,
+		RolloverPolicy:     rolloverPolicy,
+		RolloverCapPercent: rolloverCapPercent,
+		RolloverCapAmount:  rolloverCapAmount,
+		Domain:             req.Domain,
 	}
 	if err = quotaPool.Create(ctx, data); err != nil {
 		return nil, gerror.Wrap(err, "新增配额池失败")
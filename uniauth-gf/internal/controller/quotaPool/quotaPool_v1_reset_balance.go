@@ -11,6 +11,9 @@ import (
 
 func (c *ControllerV1) ResetBalance(ctx context.Context, req *v1.ResetBalanceReq) (res *v1.ResetBalanceRes, err error) {
 	res = &v1.ResetBalanceRes{}
+	if err = svc.CheckAdminDomain(ctx, req.QuotaPool); err != nil {
+		return nil, err
+	}
 
 	_, err = svc.ResetBalance(ctx, req.QuotaPool, true)
 	if err != nil {
@@ -0,0 +1,32 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+	"uniauth-gf/internal/service/poolAlert"
+)
+
+func (c *ControllerV1) TestAlertRule(ctx context.Context, req *v1.TestAlertRuleReq) (res *v1.TestAlertRuleRes, err error) {
+	var rule *entity.QuotapoolAlertRule
+	if err = dao.QuotapoolAlertRule.Ctx(ctx).WherePri(req.Id).Scan(&rule); err != nil {
+		return nil, gerror.Wrap(err, "查询告警规则失败")
+	}
+	if rule == nil {
+		return nil, gerror.Newf("告警规则不存在，请重新检查：%v", req.Id)
+	}
+	eventId, status, sendErr := poolAlert.SendTest(ctx, rule)
+	res = &v1.TestAlertRuleRes{
+		OK:             sendErr == nil,
+		EventId:        eventId,
+		ResponseStatus: status,
+	}
+	if sendErr != nil {
+		res.Error = sendErr.Error()
+	}
+	return
+}
@@ -0,0 +1,24 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/service/quotaPool"
+)
+
+func (c *ControllerV1) TopUpExtraQuota(ctx context.Context, req *v1.TopUpExtraQuotaReq) (res *v1.TopUpExtraQuotaRes, err error) {
+	if err = quotaPool.CheckAdminDomain(ctx, req.QuotaPoolName); err != nil {
+		return nil, err
+	}
+	grantId, err := quotaPool.TopUp(ctx, req.QuotaPoolName, req.Amount, req.ExpiresAt, req.Remark)
+	if err != nil {
+		return nil, gerror.Wrap(err, "充值加油包失败")
+	}
+	return &v1.TopUpExtraQuotaRes{
+		OK:      true,
+		GrantId: grantId,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/api/quotaPool/v1"
+	"uniauth-gf/internal/service/quotaPool"
+)
+
+func (c *ControllerV1) TransferExtraQuota(ctx context.Context, req *v1.TransferExtraQuotaReq) (res *v1.TransferExtraQuotaRes, err error) {
+	if err = quotaPool.CheckAdminDomain(ctx, req.From, req.To); err != nil {
+		return nil, err
+	}
+	if err = quotaPool.Transfer(ctx, req.From, req.To, req.Amount, req.Remark); err != nil {
+		return nil, gerror.Wrap(err, "转移加油包失败")
+	}
+	return &v1.TransferExtraQuotaRes{
+		OK: true,
+	}, nil
+}
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// adminSessionDao is the data access object for the table admin_session.
+// You can define custom methods on it to extend its functionality as needed.
+type adminSessionDao struct {
+	*internal.AdminSessionDao
+}
+
+var (
+	// AdminSession is a globally accessible object for table admin_session operations.
+	AdminSession = adminSessionDao{internal.NewAdminSessionDao()}
+)
+
+// Add your custom methods and functionality below.
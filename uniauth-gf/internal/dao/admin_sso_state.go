@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// adminSsoStateDao is the data access object for the table admin_sso_state.
+// You can define custom methods on it to extend its functionality as needed.
+type adminSsoStateDao struct {
+	*internal.AdminSsoStateDao
+}
+
+var (
+	// AdminSsoState is a globally accessible object for table admin_sso_state operations.
+	AdminSsoState = adminSsoStateDao{internal.NewAdminSsoStateDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// billingUsageDailyDao is the data access object for the table billing_usage_daily.
+// You can define custom methods on it to extend its functionality as needed.
+type billingUsageDailyDao struct {
+	*internal.BillingUsageDailyDao
+}
+
+var (
+	// BillingUsageDaily is a globally accessible object for table billing_usage_daily operations.
+	BillingUsageDaily = billingUsageDailyDao{internal.NewBillingUsageDailyDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// casbinAuditLogDao is the data access object for the table casbin_audit_log.
+// You can define custom methods on it to extend its functionality as needed.
+type casbinAuditLogDao struct {
+	*internal.CasbinAuditLogDao
+}
+
+var (
+	// CasbinAuditLog is a globally accessible object for table casbin_audit_log operations.
+	CasbinAuditLog = casbinAuditLogDao{internal.NewCasbinAuditLogDao()}
+)
+
+// Add your custom methods and functionality below.
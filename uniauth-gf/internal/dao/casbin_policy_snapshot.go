@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// casbinPolicySnapshotDao is the data access object for the table casbin_policy_snapshot.
+// You can define custom methods on it to extend its functionality as needed.
+type casbinPolicySnapshotDao struct {
+	*internal.CasbinPolicySnapshotDao
+}
+
+var (
+	// CasbinPolicySnapshot is a globally accessible object for table casbin_policy_snapshot operations.
+	CasbinPolicySnapshot = casbinPolicySnapshotDao{internal.NewCasbinPolicySnapshotDao()}
+)
+
+// Add your custom methods and functionality below.
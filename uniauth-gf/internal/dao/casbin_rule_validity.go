@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// casbinRuleValidityDao is the data access object for the table casbin_rule_validity.
+// You can define custom methods on it to extend its functionality as needed.
+type casbinRuleValidityDao struct {
+	*internal.CasbinRuleValidityDao
+}
+
+var (
+	// CasbinRuleValidity is a globally accessible object for table casbin_rule_validity operations.
+	CasbinRuleValidity = casbinRuleValidityDao{internal.NewCasbinRuleValidityDao()}
+)
+
+// Add your custom methods and functionality below.
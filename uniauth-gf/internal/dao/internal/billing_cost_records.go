@@ -31,6 +31,7 @@ type BillingCostRecordsColumns struct {
 	Remark       string // 备注信息
 	CreatedAt    string // 创建时间
 	OriginalCost string // 原始费用
+	RequestId    string // 幂等键
 }
 
 // billingCostRecordsColumns holds the columns for the table billing_cost_records.
@@ -45,6 +46,7 @@ var billingCostRecordsColumns = BillingCostRecordsColumns{
 	Remark:       "remark",
 	CreatedAt:    "created_at",
 	OriginalCost: "original_cost",
+	RequestId:    "request_id",
 }
 
 // NewBillingCostRecordsDao creates and returns a new DAO object for table data access.
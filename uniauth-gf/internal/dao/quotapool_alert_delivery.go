@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// quotapoolAlertDeliveryDao is the data access object for the table quotapool_alert_delivery.
+// You can define custom methods on it to extend its functionality as needed.
+type quotapoolAlertDeliveryDao struct {
+	*internal.QuotapoolAlertDeliveryDao
+}
+
+var (
+	// QuotapoolAlertDelivery is a globally accessible object for table quotapool_alert_delivery operations.
+	QuotapoolAlertDelivery = quotapoolAlertDeliveryDao{internal.NewQuotapoolAlertDeliveryDao()}
+)
+
+// Add your custom methods and functionality below.
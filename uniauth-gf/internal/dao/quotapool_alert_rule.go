@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// quotapoolAlertRuleDao is the data access object for the table quotapool_alert_rule.
+// You can define custom methods on it to extend its functionality as needed.
+type quotapoolAlertRuleDao struct {
+	*internal.QuotapoolAlertRuleDao
+}
+
+var (
+	// QuotapoolAlertRule is a globally accessible object for table quotapool_alert_rule operations.
+	QuotapoolAlertRule = quotapoolAlertRuleDao{internal.NewQuotapoolAlertRuleDao()}
+)
+
+// Add your custom methods and functionality below.
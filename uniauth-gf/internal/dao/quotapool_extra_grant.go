@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// quotapoolExtraGrantDao is the data access object for the table quotapool_extra_grant.
+// You can define custom methods on it to extend its functionality as needed.
+type quotapoolExtraGrantDao struct {
+	*internal.QuotapoolExtraGrantDao
+}
+
+var (
+	// QuotapoolExtraGrant is a globally accessible object for table quotapool_extra_grant operations.
+	QuotapoolExtraGrant = quotapoolExtraGrantDao{internal.NewQuotapoolExtraGrantDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// quotapoolLedgerDao is the data access object for the table quotapool_ledger.
+// You can define custom methods on it to extend its functionality as needed.
+type quotapoolLedgerDao struct {
+	*internal.QuotapoolLedgerDao
+}
+
+var (
+	// QuotapoolLedger is a globally accessible object for table quotapool_ledger operations.
+	QuotapoolLedger = quotapoolLedgerDao{internal.NewQuotapoolLedgerDao()}
+)
+
+// Add your custom methods and functionality below.
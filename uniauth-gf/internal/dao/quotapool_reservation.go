@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// quotapoolReservationDao is the data access object for the table quotapool_reservation.
+// You can define custom methods on it to extend its functionality as needed.
+type quotapoolReservationDao struct {
+	*internal.QuotapoolReservationDao
+}
+
+var (
+	// QuotapoolReservation is a globally accessible object for table quotapool_reservation operations.
+	QuotapoolReservation = quotapoolReservationDao{internal.NewQuotapoolReservationDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// rateLimitBucketDao is the data access object for the table rate_limit_bucket.
+// You can define custom methods on it to extend its functionality as needed.
+type rateLimitBucketDao struct {
+	*internal.RateLimitBucketDao
+}
+
+var (
+	// RateLimitBucket is a globally accessible object for table rate_limit_bucket operations.
+	RateLimitBucket = rateLimitBucketDao{internal.NewRateLimitBucketDao()}
+)
+
+// Add your custom methods and functionality below.
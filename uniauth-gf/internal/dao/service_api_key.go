@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"uniauth-gf/internal/dao/internal"
+)
+
+// serviceApiKeyDao is the data access object for the table service_api_key.
+// You can define custom methods on it to extend its functionality as needed.
+type serviceApiKeyDao struct {
+	*internal.ServiceApiKeyDao
+}
+
+var (
+	// ServiceApiKey is a globally accessible object for table service_api_key operations.
+	ServiceApiKey = serviceApiKeyDao{internal.NewServiceApiKeyDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -36,6 +36,11 @@ func UniResMiddleware(r *ghttp.Request) {
 		return
 	}
 
+	// 重定向响应不处理
+	if r.Response.Status >= 300 && r.Response.Status < 400 && r.Response.Header().Get("Location") != "" {
+		return
+	}
+
 	// 流式响应不处理
 	mediaType, _, _ := mime.ParseMediaType(r.Response.Header().Get("Content-Type"))
 	for _, ct := range streamContentType {
@@ -23,4 +23,5 @@ type BillingCostRecords struct {
 	Remark       *gjson.Json // 备注信息
 	CreatedAt    *gtime.Time // 创建时间
 	OriginalCost any         // 原始费用
+	RequestId    any         // 幂等键
 }
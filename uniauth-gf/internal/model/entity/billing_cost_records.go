@@ -22,4 +22,5 @@ type BillingCostRecords struct {
 	Remark       *gjson.Json     `json:"remark"       orm:"remark"        description:"备注信息"` // 备注信息
 	CreatedAt    *gtime.Time     `json:"createdAt"    orm:"created_at"    description:"创建时间"` // 创建时间
 	OriginalCost decimal.Decimal `json:"originalCost" orm:"original_cost" description:"原始费用"` // 原始费用
+	RequestId    string          `json:"requestId"    orm:"request_id"    description:"幂等键"`  // 幂等键
 }
@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"golang.org/x/crypto/bcrypt"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+	"uniauth-gf/internal/service/casbin"
+)
+
+// SuperuserRole 可以调用所有管理接口的角色，初始化管理员会加入这个角色
+const SuperuserRole = "admin_superuser"
+
+// superuserMethods 超级管理员角色被授权的 HTTP 方法
+var superuserMethods = []string{"GET", "POST", "PUT", "DELETE"}
+
+// Subject 管理员在 Casbin 中的 subject。加上前缀避免与用户 UPN 和配额池名称冲突。
+func Subject(username string) string {
+	return "admin:" + username
+}
+
+// HashPassword 使用 bcrypt 计算密码哈希
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", gerror.Wrap(err, "计算密码哈希失败")
+	}
+	return string(hash), nil
+}
+
+// GetAccount 查询管理员账号，不存在时返回 nil
+func GetAccount(ctx context.Context, username string) (account *entity.AdminAccount, err error) {
+	if err = dao.AdminAccount.Ctx(ctx).Where("username", username).Scan(&account); err != nil {
+		return nil, gerror.Wrapf(err, "查询管理员 %v 失败", username)
+	}
+	return
+}
+
+// CreateAccount 创建管理员账号。新账号没有任何 Casbin 权限，需要另外为 admin:<username> 授权。
+func CreateAccount(ctx context.Context, username, password, displayName string) error {
+	if existing, err := GetAccount(ctx, username); err != nil {
+		return err
+	} else if existing != nil {
+		return gerror.Newf("管理员 %v 已经存在", username)
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	if _, err = dao.AdminAccount.Ctx(ctx).Data(g.Map{
+		"username":      username,
+		"password_hash": hash,
+		"display_name":  displayName,
+	}).Insert(); err != nil {
+		return gerror.Wrapf(err, "创建管理员 %v 失败", username)
+	}
+	return nil
+}
+
+// AccountUpdate 管理员账号的修改项，为 nil 的字段不修改
+type AccountUpdate struct {
+	Password    *string
+	DisplayName *string
+	Disabled    *bool
+}
+
+// UpdateAccount 修改管理员账号。修改密码或禁用账号时，注销该管理员的所有会话。
+func UpdateAccount(ctx context.Context, username string, update *AccountUpdate) error {
+	return dao.AdminAccount.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		var account *entity.AdminAccount
+		if err := dao.AdminAccount.Ctx(ctx).Where("username", username).LockUpdate().Scan(&account); err != nil {
+			return gerror.Wrapf(err, "查询管理员 %v 失败", username)
+		}
+		if account == nil {
+			return gerror.Newf("管理员 %v 不存在", username)
+		}
+
+		data := g.Map{"updated_at": gtime.Now()}
+		revoke := false
+		if update.Password != nil {
+			hash, err := HashPassword(*update.Password)
+			if err != nil {
+				return err
+			}
+			data["password_hash"] = hash
+			revoke = true
+		}
+		if update.DisplayName != nil {
+			data["display_name"] = *update.DisplayName
+		}
+		if update.Disabled != nil {
+			data["disabled"] = *update.Disabled
+			revoke = revoke || *update.Disabled
+		}
+		if _, err := dao.AdminAccount.Ctx(ctx).Where("username", username).Data(data).Update(); err != nil {
+			return gerror.Wrapf(err, "修改管理员 %v 失败", username)
+		}
+		if revoke {
+			return RevokeSessions(ctx, username)
+		}
+		return nil
+	})
+}
+
+// DeleteAccount 删除管理员账号，同时注销其所有会话并删除 admin:<username> 的所有 Casbin 规则。
+func DeleteAccount(ctx context.Context, username string) error {
+	err := dao.AdminAccount.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		res, err := dao.AdminAccount.Ctx(ctx).Where("username", username).Delete()
+		if err != nil {
+			return gerror.Wrapf(err, "删除管理员 %v 失败", username)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return gerror.Newf("管理员 %v 不存在", username)
+		}
+		return RevokeSessions(ctx, username)
+	})
+	if err != nil {
+		return err
+	}
+	subject := Subject(username)
+	if _, err = casbin.RemoveFilteredPolicy(ctx, "admin.DeleteAccount", 0, subject); err != nil {
+		return gerror.Wrapf(err, "删除管理员 %v 的权限失败", username)
+	}
+	if _, err = casbin.RemoveFilteredGroupingPolicy(ctx, "admin.DeleteAccount", 0, subject); err != nil {
+		return gerror.Wrapf(err, "删除管理员 %v 的角色失败", username)
+	}
+	return nil
+}
+
+// EnsureBootstrapAdmin 还没有任何管理员账号时，用配置中的 uniauth.account 和 uniauth.password 创建初始管理员，
+// 并授予超级管理员角色。已经有管理员账号或没有配置账号时不做任何操作。
+func EnsureBootstrapAdmin(ctx context.Context) error {
+	count, err := dao.AdminAccount.Ctx(ctx).Count()
+	if err != nil {
+		return gerror.Wrap(err, "查询管理员数量失败")
+	}
+	if count > 0 {
+		return nil
+	}
+	username := g.Cfg().MustGetWithEnv(ctx, "uniauth.account").String()
+	password := g.Cfg().MustGetWithEnv(ctx, "uniauth.password").String()
+	if username == "" || password == "" {
+		g.Log().Warning(ctx, "没有任何管理员账号，且没有配置 uniauth.account 和 uniauth.password，管理接口将无法访问")
+		return nil
+	}
+	if err = CreateAccount(ctx, username, password, ""); err != nil {
+		return err
+	}
+
+	policies := make([][]string, 0, len(superuserMethods))
+	for _, method := range superuserMethods {
+		policies = append(policies, []string{SuperuserRole, casbin.DefaultDomain, "/*", method, "allow"})
+	}
+	if _, err = casbin.AddPolicies(ctx, "admin.EnsureBootstrapAdmin", policies, true); err != nil {
+		return gerror.Wrap(err, "添加超级管理员权限失败")
+	}
+	if _, err = casbin.AddGroupingPolicies(ctx, "admin.EnsureBootstrapAdmin", [][]string{{Subject(username), SuperuserRole, casbin.DefaultDomain}}, true); err != nil {
+		return gerror.Wrap(err, "授予初始管理员超级管理员角色失败")
+	}
+	g.Log().Infof(ctx, "已创建初始管理员 %v", username)
+	return nil
+}
@@ -0,0 +1,453 @@
+package admin
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// oidcHTTPTimeout 请求身份提供方的超时时间
+const oidcHTTPTimeout = 10 * time.Second
+
+// oidcClockSkew 校验 ID Token 过期时间时允许的时钟误差
+const oidcClockSkew = time.Minute
+
+// ErrSsoUserUnknown 身份提供方返回的 UPN 不在用户信息表中
+var ErrSsoUserUnknown = gerror.New("单点登录的用户不存在")
+
+// oidcConfig 单点登录配置，对应配置文件中的 oidc 节点
+type oidcConfig struct {
+	Issuer           string
+	ClientId         string
+	ClientSecret     string
+	RedirectUrl      string
+	Scopes           []string
+	UpnClaim         string
+	PostLoginUrl     string
+	AllowedRedirects []string
+	StateTtl         time.Duration
+}
+
+func loadOidcConfig(ctx context.Context) (*oidcConfig, error) {
+	if !g.Cfg().MustGetWithEnv(ctx, "oidc.enabled", false).Bool() {
+		return nil, gerror.New("没有启用单点登录")
+	}
+	cfg := &oidcConfig{
+		Issuer:           strings.TrimSuffix(g.Cfg().MustGetWithEnv(ctx, "oidc.issuer").String(), "/"),
+		ClientId:         g.Cfg().MustGetWithEnv(ctx, "oidc.clientId").String(),
+		ClientSecret:     g.Cfg().MustGetWithEnv(ctx, "oidc.clientSecret").String(),
+		RedirectUrl:      g.Cfg().MustGetWithEnv(ctx, "oidc.redirectUrl").String(),
+		Scopes:           g.Cfg().MustGetWithEnv(ctx, "oidc.scopes", []string{"openid", "profile", "email"}).Strings(),
+		UpnClaim:         g.Cfg().MustGetWithEnv(ctx, "oidc.upnClaim", "upn").String(),
+		PostLoginUrl:     g.Cfg().MustGetWithEnv(ctx, "oidc.postLoginUrl").String(),
+		AllowedRedirects: g.Cfg().MustGetWithEnv(ctx, "oidc.allowedRedirects").Strings(),
+		StateTtl:         g.Cfg().MustGetWithEnv(ctx, "oidc.stateTtl", "10m").Duration(),
+	}
+	if cfg.Issuer == "" || cfg.ClientId == "" || cfg.RedirectUrl == "" {
+		return nil, gerror.New("单点登录配置不完整，需要 oidc.issuer、oidc.clientId 和 oidc.redirectUrl")
+	}
+	return cfg, nil
+}
+
+// allowRedirect 判断登录完成后能否跳转到 redirect，避免会话令牌被带到第三方站点。
+// 只允许 oidc.postLoginUrl，以及与 oidc.allowedRedirects 中某个地址的协议和主机完全相同、
+// 路径等于该地址的路径或在其下一级目录中的地址。
+func (cfg *oidcConfig) allowRedirect(redirect string) bool {
+	if redirect == cfg.PostLoginUrl {
+		return true
+	}
+	target, err := url.Parse(redirect)
+	if err != nil || target.Host == "" || target.User != nil {
+		return false
+	}
+	for _, prefix := range cfg.AllowedRedirects {
+		allowed, err := url.Parse(prefix)
+		if prefix == "" || err != nil || allowed.Host == "" {
+			continue
+		}
+		if !strings.EqualFold(target.Scheme, allowed.Scheme) || !strings.EqualFold(target.Host, allowed.Host) {
+			continue
+		}
+		base := strings.TrimSuffix(allowed.Path, "/")
+		if base == "" || target.Path == base || strings.HasPrefix(target.Path, base+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// oidcProvider 身份提供方的 Discovery 文档中用到的字段
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+// oidcCache 缓存 Discovery 文档和签名公钥。Discovery 文档每小时刷新；遇到未知的 kid 时刷新公钥，最多每分钟一次。
+var oidcCache struct {
+	sync.Mutex
+	issuer        string
+	provider      *oidcProvider
+	fetchedAt     time.Time
+	keys          map[string]*rsa.PublicKey
+	keysFetchedAt time.Time
+}
+
+func fetchJson(ctx context.Context, u string, v any) error {
+	resp, err := g.Client().Timeout(oidcHTTPTimeout).Get(ctx, u)
+	if err != nil {
+		return gerror.Wrapf(err, "请求 %v 失败", u)
+	}
+	defer resp.Close()
+	body := resp.ReadAll()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return gerror.Newf("%v 返回非 2xx 状态码：%d", u, resp.StatusCode)
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return gerror.Wrapf(err, "%v 返回信息反序列化失败", u)
+	}
+	return nil
+}
+
+func getOidcProvider(ctx context.Context, cfg *oidcConfig) (*oidcProvider, error) {
+	oidcCache.Lock()
+	defer oidcCache.Unlock()
+	if oidcCache.provider != nil && oidcCache.issuer == cfg.Issuer && time.Since(oidcCache.fetchedAt) < time.Hour {
+		return oidcCache.provider, nil
+	}
+	var provider *oidcProvider
+	if err := fetchJson(ctx, cfg.Issuer+"/.well-known/openid-configuration", &provider); err != nil {
+		return nil, gerror.Wrap(err, "获取身份提供方配置失败")
+	}
+	if provider == nil || provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JwksUri == "" {
+		return nil, gerror.New("身份提供方配置缺少 authorization_endpoint、token_endpoint 或 jwks_uri")
+	}
+	if strings.TrimSuffix(provider.Issuer, "/") != cfg.Issuer {
+		return nil, gerror.Newf("身份提供方的 issuer %v 与配置的 %v 不一致", provider.Issuer, cfg.Issuer)
+	}
+	if oidcCache.issuer != cfg.Issuer {
+		oidcCache.keys = nil
+		oidcCache.keysFetchedAt = time.Time{}
+	}
+	oidcCache.issuer, oidcCache.provider, oidcCache.fetchedAt = cfg.Issuer, provider, time.Now()
+	return provider, nil
+}
+
+func getSigningKey(ctx context.Context, provider *oidcProvider, kid string) (*rsa.PublicKey, error) {
+	oidcCache.Lock()
+	defer oidcCache.Unlock()
+	if key, ok := oidcCache.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(oidcCache.keysFetchedAt) < time.Minute {
+		return nil, gerror.Newf("身份提供方没有 kid 为 %v 的签名公钥", kid)
+	}
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := fetchJson(ctx, provider.JwksUri, &jwks); err != nil {
+		return nil, gerror.Wrap(err, "获取身份提供方签名公钥失败")
+	}
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			g.Log().Warningf(ctx, "忽略身份提供方格式错误的签名公钥 %v", k.Kid)
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	oidcCache.keys, oidcCache.keysFetchedAt = keys, time.Now()
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, gerror.Newf("身份提供方没有 kid 为 %v 的签名公钥", kid)
+}
+
+// verifyIdToken 校验 ID Token 的签名（只支持 RS256）、issuer、audience、过期时间和 nonce，返回其中的声明
+func verifyIdToken(ctx context.Context, cfg *oidcConfig, provider *oidcProvider, idToken, nonce string) (map[string]any, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, gerror.New("ID Token 格式错误")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if raw, err := base64.RawURLEncoding.DecodeString(parts[0]); err != nil || json.Unmarshal(raw, &header) != nil {
+		return nil, gerror.New("ID Token 头部格式错误")
+	}
+	if header.Alg != "RS256" {
+		return nil, gerror.Newf("不支持 ID Token 的签名算法 %v", header.Alg)
+	}
+	key, err := getSigningKey(ctx, provider, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, gerror.New("ID Token 签名格式错误")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, gerror.New("ID Token 签名无效")
+	}
+
+	var claims map[string]any
+	if raw, err := base64.RawURLEncoding.DecodeString(parts[1]); err != nil || json.Unmarshal(raw, &claims) != nil {
+		return nil, gerror.New("ID Token 声明格式错误")
+	}
+	if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != cfg.Issuer {
+		return nil, gerror.Newf("ID Token 的 issuer %v 与配置的 %v 不一致", iss, cfg.Issuer)
+	}
+	audOk := false
+	switch aud := claims["aud"].(type) {
+	case string:
+		audOk = aud == cfg.ClientId
+	case []any:
+		for _, a := range aud {
+			audOk = audOk || a == cfg.ClientId
+		}
+	}
+	if !audOk {
+		return nil, gerror.New("ID Token 的 audience 不包含本系统")
+	}
+	exp, _ := claims["exp"].(float64)
+	if time.Unix(int64(exp), 0).Add(oidcClockSkew).Before(time.Now()) {
+		return nil, gerror.New("ID Token 已经过期")
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, gerror.New("ID Token 的 nonce 不匹配")
+	}
+	return claims, nil
+}
+
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", gerror.Wrap(err, "生成随机数失败")
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// SsoLoginUrl 开始 OIDC 授权码流程，返回身份提供方的登录地址。
+//
+// redirect 为登录完成后跳转的管理后台地址，为空时使用 oidc.postLoginUrl，允许跳转的地址见 allowRedirect。
+// state、nonce 和 PKCE code_verifier 保存在数据库中，多个实例都能处理回调。
+func SsoLoginUrl(ctx context.Context, redirect string) (string, error) {
+	cfg, err := loadOidcConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	if redirect == "" {
+		redirect = cfg.PostLoginUrl
+	}
+	if redirect == "" || !cfg.allowRedirect(redirect) {
+		return "", gerror.Newf("不允许登录后跳转到 %v", redirect)
+	}
+	provider, err := getOidcProvider(ctx, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomString(24)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomString(24)
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+	if _, err = dao.AdminSsoState.Ctx(ctx).Data(g.Map{
+		"state":         state,
+		"nonce":         nonce,
+		"code_verifier": verifier,
+		"redirect":      redirect,
+		"expires_at":    gtime.Now().Add(cfg.StateTtl),
+	}).Insert(); err != nil {
+		return "", gerror.Wrap(err, "保存单点登录请求失败")
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {cfg.ClientId},
+		"redirect_uri":          {cfg.RedirectUrl},
+		"scope":                 {strings.Join(cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(provider.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return provider.AuthorizationEndpoint + sep + query.Encode(), nil
+}
+
+// takeSsoState 取出并删除 state 对应的单点登录请求，每个 state 只能使用一次。不存在或已过期时返回 nil。
+func takeSsoState(ctx context.Context, state string) (ssoState *entity.AdminSsoState, err error) {
+	err = dao.AdminSsoState.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if err := dao.AdminSsoState.Ctx(ctx).Where("state", state).LockUpdate().Scan(&ssoState); err != nil {
+			return gerror.Wrap(err, "查询单点登录请求失败")
+		}
+		if ssoState == nil {
+			return nil
+		}
+		if _, err := dao.AdminSsoState.Ctx(ctx).Where("state", state).Delete(); err != nil {
+			return gerror.Wrap(err, "删除单点登录请求失败")
+		}
+		return nil
+	})
+	if err != nil || ssoState == nil || ssoState.ExpiresAt.Before(gtime.Now()) {
+		return nil, err
+	}
+	return
+}
+
+// exchangeCode 用授权码向身份提供方换取 ID Token
+func exchangeCode(ctx context.Context, cfg *oidcConfig, provider *oidcProvider, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {cfg.RedirectUrl},
+		"client_id":     {cfg.ClientId},
+		"client_secret": {cfg.ClientSecret},
+		"code_verifier": {verifier},
+	}
+	resp, err := g.Client().Timeout(oidcHTTPTimeout).
+		ContentType("application/x-www-form-urlencoded").
+		Post(ctx, provider.TokenEndpoint, form.Encode())
+	if err != nil {
+		return "", gerror.Wrap(err, "请求身份提供方换取令牌失败")
+	}
+	defer resp.Close()
+	var token struct {
+		IdToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err = json.Unmarshal(resp.ReadAll(), &token); err != nil {
+		return "", gerror.Wrapf(err, "身份提供方返回的令牌反序列化失败，状态码：%d", resp.StatusCode)
+	}
+	if token.Error != "" {
+		return "", gerror.Newf("身份提供方拒绝换取令牌：%v %v", token.Error, token.ErrorDescription)
+	}
+	if token.IdToken == "" {
+		return "", gerror.New("身份提供方没有返回 ID Token")
+	}
+	return token.IdToken, nil
+}
+
+// SsoCallback 完成 OIDC 授权码流程：校验 state，用授权码换取 ID Token 并校验，按 oidc.upnClaim（默认 upn）声明找到用户，
+// 为其创建会话。返回会话令牌、会话和登录完成后跳转的地址。
+//
+// 单点登录的管理员在 Casbin 中的 subject 为 admin:<UPN>，能调用哪些管理接口由其 Casbin 权限决定。
+func SsoCallback(ctx context.Context, code, state, clientIp, userAgent string) (token string, session *entity.AdminSession, redirect string, err error) {
+	cfg, err := loadOidcConfig(ctx)
+	if err != nil {
+		return "", nil, "", err
+	}
+	ssoState, err := takeSsoState(ctx, state)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if ssoState == nil {
+		return "", nil, "", gerror.New("单点登录请求不存在或已过期，请重新登录")
+	}
+	provider, err := getOidcProvider(ctx, cfg)
+	if err != nil {
+		return "", nil, "", err
+	}
+	idToken, err := exchangeCode(ctx, cfg, provider, code, ssoState.CodeVerifier)
+	if err != nil {
+		return "", nil, "", err
+	}
+	claims, err := verifyIdToken(ctx, cfg, provider, idToken, ssoState.Nonce)
+	if err != nil {
+		return "", nil, "", err
+	}
+	upn, _ := claims[cfg.UpnClaim].(string)
+	if upn = strings.TrimSpace(upn); upn == "" {
+		return "", nil, "", gerror.Newf("ID Token 中没有 %v 声明", cfg.UpnClaim)
+	}
+
+	user, err := GetSsoUser(ctx, upn)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if user == nil {
+		g.Log().Infof(ctx, "单点登录失败：UPN %v 不在用户信息表中", upn)
+		return "", nil, "", ErrSsoUserUnknown
+	}
+	if account, err := GetAccount(ctx, user.Upn); err != nil {
+		return "", nil, "", err
+	} else if account != nil && account.Disabled {
+		return "", nil, "", gerror.Newf("管理员 %v 已被禁用", user.Upn)
+	}
+	if token, session, err = createSession(ctx, user.Upn, ProviderOidc, clientIp, userAgent); err != nil {
+		return "", nil, "", err
+	}
+	g.Log().Infof(ctx, "管理员 %v 通过单点登录创建会话", user.Upn)
+	return token, session, ssoState.Redirect, nil
+}
+
+// GetSsoUser 按 UPN 查询用户信息，不区分大小写，不存在时返回 nil
+func GetSsoUser(ctx context.Context, upn string) (user *entity.UserinfosUserInfos, err error) {
+	if err = dao.UserinfosUserInfos.Ctx(ctx).Where("LOWER(upn) = LOWER(?)", upn).Scan(&user); err != nil {
+		return nil, gerror.Wrapf(err, "查询用户 %v 失败", upn)
+	}
+	return
+}
+
+// PurgeExpiredSsoStates 删除已经过期的单点登录请求，返回删除的条数
+func PurgeExpiredSsoStates(ctx context.Context) (int64, error) {
+	res, err := dao.AdminSsoState.Ctx(ctx).WhereLT("expires_at", gtime.Now()).Delete()
+	if err != nil {
+		return 0, gerror.Wrap(err, "清理过期单点登录请求失败")
+	}
+	return res.RowsAffected()
+}
+
+// SsoRedirectUrl 拼接登录完成后的跳转地址。会话令牌放在 URL 片段中，不会被发送到管理后台的服务器或记录在访问日志里。
+func SsoRedirectUrl(redirect, token string, session *entity.AdminSession) string {
+	if i := strings.Index(redirect, "#"); i >= 0 {
+		redirect = redirect[:i]
+	}
+	return redirect + "#" + url.Values{
+		"token":     {token},
+		"expiresAt": {session.ExpiresAt.Format("c")},
+	}.Encode()
+}
@@ -0,0 +1,158 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"golang.org/x/crypto/bcrypt"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+	"uniauth-gf/internal/service/casbin"
+)
+
+// tokenPrefix 会话令牌前缀，便于在日志和密钥扫描中识别
+const tokenPrefix = "uas_"
+
+// 会话的登录方式
+const (
+	ProviderPassword = "password"
+	ProviderOidc     = "oidc"
+)
+
+// ErrInvalidCredentials 用户名或密码错误，或账号已被禁用
+var ErrInvalidCredentials = gerror.New("用户名或密码错误")
+
+// dummyHash 用户不存在时也做一次 bcrypt 比较，避免通过响应时间判断用户名是否存在
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("uniauth-dummy-password"), bcrypt.DefaultCost)
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Login 校验用户名和密码，成功时创建会话并返回令牌。令牌只在这里返回一次，数据库中只保存哈希。
+//
+// 会话有效期由 admin.sessionTtl 配置，默认 12 小时。
+func Login(ctx context.Context, username, password, clientIp, userAgent string) (token string, session *entity.AdminSession, err error) {
+	account, err := GetAccount(ctx, username)
+	if err != nil {
+		return "", nil, err
+	}
+	hash := dummyHash
+	if account != nil {
+		hash = []byte(account.PasswordHash)
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || account == nil || account.Disabled {
+		return "", nil, ErrInvalidCredentials
+	}
+
+	if token, session, err = createSession(ctx, username, ProviderPassword, clientIp, userAgent); err != nil {
+		return "", nil, err
+	}
+	if _, err = dao.AdminAccount.Ctx(ctx).Where("username", username).Data(g.Map{"last_login_at": gtime.Now()}).Update(); err != nil {
+		g.Log().Warningf(ctx, "更新管理员 %v 的最近登录时间失败: %v", username, err)
+	}
+	return token, session, nil
+}
+
+// createSession 为管理员创建会话并返回令牌。会话有效期由 admin.sessionTtl 配置，默认 12 小时。
+func createSession(ctx context.Context, username, provider, clientIp, userAgent string) (token string, session *entity.AdminSession, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", nil, gerror.Wrap(err, "生成会话令牌失败")
+	}
+	token = tokenPrefix + hex.EncodeToString(buf)
+	ttl := g.Cfg().MustGetWithEnv(ctx, "admin.sessionTtl", "12h").Duration()
+	session = &entity.AdminSession{
+		TokenHash: hashToken(token),
+		Username:  username,
+		Provider:  provider,
+		ExpiresAt: gtime.Now().Add(ttl),
+		ClientIp:  clientIp,
+		UserAgent: userAgent,
+	}
+	if session.Id, err = dao.AdminSession.Ctx(ctx).Data(g.Map{
+		"token_hash": session.TokenHash,
+		"username":   session.Username,
+		"provider":   session.Provider,
+		"expires_at": session.ExpiresAt,
+		"client_ip":  session.ClientIp,
+		"user_agent": session.UserAgent,
+	}).InsertAndGetId(); err != nil {
+		return "", nil, gerror.Wrap(err, "创建会话失败")
+	}
+	return token, session, nil
+}
+
+// Authenticate 校验会话令牌，返回对应的会话。令牌无效、过期、已注销或账号已被禁用时返回 nil。
+//
+// 单点登录的会话不要求有管理员账号，但 UPN 同名的管理员账号被禁用时同样失效。
+func Authenticate(ctx context.Context, token string) (session *entity.AdminSession, err error) {
+	if token == "" {
+		return nil, nil
+	}
+	if err = dao.AdminSession.Ctx(ctx).As("s").
+		LeftJoin(dao.AdminAccount.Table()+" a", "a.username = s.username").
+		Fields("s.*").
+		Where("s.token_hash", hashToken(token)).
+		WhereNull("s.revoked_at").
+		WhereGT("s.expires_at", gtime.Now()).
+		Where("(s.provider = ? AND a.disabled = FALSE) OR (s.provider = ? AND a.disabled IS NOT TRUE)", ProviderPassword, ProviderOidc).
+		Scan(&session); err != nil {
+		return nil, gerror.Wrap(err, "校验会话失败")
+	}
+	return
+}
+
+// Logout 注销令牌对应的会话，令牌无效时不做任何操作
+func Logout(ctx context.Context, token string) error {
+	if _, err := dao.AdminSession.Ctx(ctx).
+		Where("token_hash", hashToken(token)).
+		WhereNull("revoked_at").
+		Data(g.Map{"revoked_at": gtime.Now()}).
+		Update(); err != nil {
+		return gerror.Wrap(err, "注销会话失败")
+	}
+	return nil
+}
+
+// RevokeSessions 注销管理员的所有会话
+func RevokeSessions(ctx context.Context, username string) error {
+	if _, err := dao.AdminSession.Ctx(ctx).
+		Where("username", username).
+		WhereNull("revoked_at").
+		Data(g.Map{"revoked_at": gtime.Now()}).
+		Update(); err != nil {
+		return gerror.Wrapf(err, "注销管理员 %v 的会话失败", username)
+	}
+	return nil
+}
+
+// PurgeExpiredSessions 删除已经过期或注销超过一天的会话，返回删除的条数
+func PurgeExpiredSessions(ctx context.Context) (int64, error) {
+	before := gtime.Now().AddDate(0, 0, -1)
+	res, err := dao.AdminSession.Ctx(ctx).
+		Where("expires_at < ? OR revoked_at < ?", before, before).
+		Delete()
+	if err != nil {
+		return 0, gerror.Wrap(err, "清理过期会话失败")
+	}
+	return res.RowsAffected()
+}
+
+// Authorize 判断管理员能否在域 dom 中调用某个管理接口。obj 为请求路径，act 为 HTTP 方法，例如 ("/auth/admin/policies/add", "POST")。
+// 部门管理员只在自己的域中有权限；default 域中的权限对所有域生效。
+func Authorize(username, dom, path, method string) (bool, error) {
+	e := casbin.GetEnforcer()
+	allow, err := e.Enforce(Subject(username), dom, path, method)
+	if err != nil || allow || dom == casbin.DefaultDomain {
+		return allow, err
+	}
+	return e.Enforce(Subject(username), casbin.DefaultDomain, path, method)
+}
@@ -0,0 +1,214 @@
+package apiKey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+	"uniauth-gf/internal/service/casbin"
+)
+
+// Key 状态
+const (
+	StatusActive  = "active"
+	StatusRevoked = "revoked"
+)
+
+// ScopeAll 不限制路由组
+const ScopeAll = "*"
+
+// keyPrefix 明文 Key 的前缀，便于在日志和密钥扫描中识别
+const keyPrefix = "uak_"
+
+// touchInterval 最近使用时间的更新间隔，避免每个请求都写数据库
+const touchInterval = time.Minute
+
+// Subject 服务身份在 Casbin 中的 subject。加上前缀避免与用户 UPN 和配额池名称冲突。
+func Subject(service string) string {
+	return "svc:" + service
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func newKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", gerror.Wrap(err, "生成 API Key 失败")
+	}
+	return keyPrefix + hex.EncodeToString(buf), nil
+}
+
+// NewKeyParams 创建 Key 的参数
+type NewKeyParams struct {
+	Service     string
+	Scopes      []string
+	Description string
+	ExpiresAt   *gtime.Time
+	RotatedFrom int64
+	CreatedBy   string
+}
+
+// Create 为服务身份创建一个 Key，返回明文 Key 和记录。明文只在这里返回一次，数据库中只保存哈希。
+func Create(ctx context.Context, params *NewKeyParams) (key string, record *entity.ServiceApiKey, err error) {
+	if key, err = newKey(); err != nil {
+		return "", nil, err
+	}
+	record = &entity.ServiceApiKey{
+		KeyPrefix:   key[:len(keyPrefix)+8],
+		KeyHash:     hashKey(key),
+		Service:     params.Service,
+		Scopes:      params.Scopes,
+		Description: params.Description,
+		Status:      StatusActive,
+		ExpiresAt:   params.ExpiresAt,
+		RotatedFrom: params.RotatedFrom,
+		CreatedBy:   params.CreatedBy,
+	}
+	data := g.Map{
+		"key_prefix":  record.KeyPrefix,
+		"key_hash":    record.KeyHash,
+		"service":     record.Service,
+		"scopes":      record.Scopes,
+		"description": record.Description,
+		"status":      record.Status,
+		"expires_at":  record.ExpiresAt,
+		"created_by":  record.CreatedBy,
+	}
+	if params.RotatedFrom != 0 {
+		data["rotated_from"] = params.RotatedFrom
+	}
+	if record.Id, err = dao.ServiceApiKey.Ctx(ctx).Data(data).InsertAndGetId(); err != nil {
+		return "", nil, gerror.Wrapf(err, "创建服务 %v 的 API Key 失败", params.Service)
+	}
+	return key, record, nil
+}
+
+// Rotate 为 Key 生成一个新的替代 Key，服务身份、路由组和备注保持不变。
+// 旧 Key 在 apiKey.rotationGrace（默认 24 小时）后过期，调用方可以在宽限期内切换到新 Key。
+func Rotate(ctx context.Context, id int64, createdBy string) (key string, record *entity.ServiceApiKey, err error) {
+	err = dao.ServiceApiKey.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		var old *entity.ServiceApiKey
+		if err := dao.ServiceApiKey.Ctx(ctx).Where("id", id).LockUpdate().Scan(&old); err != nil {
+			return gerror.Wrapf(err, "查询 API Key %v 失败", id)
+		}
+		if old == nil {
+			return gerror.Newf("API Key %v 不存在", id)
+		}
+		if !usable(old) {
+			return gerror.Newf("API Key %v 已经吊销或过期，不能轮换", id)
+		}
+
+		grace := g.Cfg().MustGetWithEnv(ctx, "apiKey.rotationGrace", "24h").Duration()
+		graceEnd := gtime.Now().Add(grace)
+		if old.ExpiresAt == nil || old.ExpiresAt.After(graceEnd) {
+			if _, err := dao.ServiceApiKey.Ctx(ctx).Where("id", id).Data(g.Map{"expires_at": graceEnd}).Update(); err != nil {
+				return gerror.Wrapf(err, "设置 API Key %v 的宽限期失败", id)
+			}
+		}
+		var err error
+		key, record, err = Create(ctx, &NewKeyParams{
+			Service:     old.Service,
+			Scopes:      old.Scopes,
+			Description: old.Description,
+			ExpiresAt:   old.ExpiresAt,
+			RotatedFrom: old.Id,
+			CreatedBy:   createdBy,
+		})
+		return err
+	})
+	return
+}
+
+// Revoke 立即吊销 Key，重复吊销不会报错
+func Revoke(ctx context.Context, id int64) error {
+	res, err := dao.ServiceApiKey.Ctx(ctx).Where("id", id).Data(g.Map{
+		"status":     StatusRevoked,
+		"revoked_at": gdb.Raw("COALESCE(revoked_at, NOW())"),
+	}).Update()
+	if err != nil {
+		return gerror.Wrapf(err, "吊销 API Key %v 失败", id)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return gerror.Newf("API Key %v 不存在", id)
+	}
+	return nil
+}
+
+func usable(record *entity.ServiceApiKey) bool {
+	return record.Status == StatusActive && (record.ExpiresAt == nil || record.ExpiresAt.After(gtime.Now()))
+}
+
+// Authenticate 校验明文 Key，返回对应的记录。Key 不存在、已吊销或已过期时返回 nil。
+func Authenticate(ctx context.Context, key string) (record *entity.ServiceApiKey, err error) {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil, nil
+	}
+	if err = dao.ServiceApiKey.Ctx(ctx).Where("key_hash", hashKey(key)).Scan(&record); err != nil {
+		return nil, gerror.Wrap(err, "校验 API Key 失败")
+	}
+	if record == nil || !usable(record) {
+		return nil, nil
+	}
+	return
+}
+
+// InScope 判断请求路径是否在 Key 允许的路由组内。路由组 billing 对应 /billing 下的所有接口。
+func InScope(record *entity.ServiceApiKey, path string) bool {
+	for _, scope := range record.Scopes {
+		if scope == ScopeAll {
+			return true
+		}
+		prefix := "/" + strings.Trim(scope, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize 判断 Key 能否调用某个接口：路径必须在 Key 的路由组内，且服务身份在 Casbin 中有权限。
+// Casbin 检查的 dom 为 default 域，obj 为请求路径，act 为 HTTP 方法。
+func Authorize(record *entity.ServiceApiKey, path, method string) (bool, error) {
+	if !InScope(record, path) {
+		return false, nil
+	}
+	return casbin.GetEnforcer().Enforce(Subject(record.Service), casbin.DefaultDomain, path, method)
+}
+
+var (
+	touchMu   sync.Mutex
+	touchedAt = map[int64]time.Time{}
+)
+
+// Touch 记录 Key 的最近使用时间和 IP。同一个 Key 每分钟最多写一次数据库，失败只记录日志。
+func Touch(ctx context.Context, record *entity.ServiceApiKey, clientIp string) {
+	now := time.Now()
+	touchMu.Lock()
+	if last, ok := touchedAt[record.Id]; ok && now.Sub(last) < touchInterval {
+		touchMu.Unlock()
+		return
+	}
+	touchedAt[record.Id] = now
+	touchMu.Unlock()
+
+	if _, err := dao.ServiceApiKey.Ctx(ctx).Where("id", record.Id).Data(g.Map{
+		"last_used_at": gtime.Now(),
+		"last_used_ip": clientIp,
+	}).Update(); err != nil {
+		g.Log().Warningf(ctx, "更新 API Key %v 的最近使用时间失败: %v", record.Id, err)
+	}
+}
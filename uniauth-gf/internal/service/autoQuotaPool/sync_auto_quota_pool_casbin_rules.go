@@ -17,14 +17,12 @@ func SyncAutoQuotaPoolCasbinRules(ctx context.Context, ruleNames []string) error
 		Eft string `json:"eft" dc:"效果"`
 	}
 
-	e := casbin.GetEnforcer()
-
 	for _, ruleName := range ruleNames {
 		// 1. 删除指定规则名称的所有现有策略
 		subject := "auto_qp_" + ruleName
-		if removed, err := e.RemoveFilteredPolicy(0, subject); err != nil {
+		if removed, err := casbin.RemoveFilteredPolicy(ctx, "autoQuotaPool.SyncAutoQuotaPoolCasbinRules", 0, subject); err != nil {
 			return gerror.Wrapf(err, "删除自动配额池规则 %s 的现有策略失败", ruleName)
-		} else if removed {
+		} else if len(removed) > 0 {
 			g.Log().Infof(ctx, "成功删除了自动配额池规则 %s 的现有策略", ruleName)
 		} else {
 			g.Log().Infof(ctx, "自动配额池规则 %s 没有现有策略需要删除", ruleName)
@@ -53,9 +51,10 @@ func SyncAutoQuotaPoolCasbinRules(ctx context.Context, ruleNames []string) error
 
 		// 为每个规则生成casbin策略
 		for _, rule := range casbinRules {
-			// 构建casbin策略: p, auto_qp_{rule_name}, {resource}, {action}, {effect}
+			// 构建casbin策略: p, auto_qp_{rule_name}, default, {resource}, {action}, {effect}
 			policy := []string{
 				"auto_qp_" + config.RuleName, // subject
+				casbin.DefaultDomain,         // domain
 				rule.Obj,                     // object (resource)
 				rule.Act,                     // action
 				rule.Eft,                     // effect
@@ -69,10 +68,10 @@ func SyncAutoQuotaPoolCasbinRules(ctx context.Context, ruleNames []string) error
 		g.Log().Infof(ctx, "没有策略需要添加")
 		return nil
 	}
-	if added, err := e.AddPolicies(allPolicies); err != nil {
+	if added, err := casbin.AddPolicies(ctx, "autoQuotaPool.SyncAutoQuotaPoolCasbinRules", allPolicies, false); err != nil {
 		return gerror.Wrapf(err, "添加casbin策略失败: %v", allPolicies)
-	} else if added {
-		g.Log().Infof(ctx, "成功添加了 %d 条casbin策略", len(allPolicies))
+	} else if len(added) > 0 {
+		g.Log().Infof(ctx, "成功添加了 %d 条casbin策略", len(added))
 	} else {
 		g.Log().Infof(ctx, "没有新策略需要添加")
 	}
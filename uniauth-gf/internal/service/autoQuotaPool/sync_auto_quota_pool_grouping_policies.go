@@ -13,6 +13,9 @@ import (
 
 func SyncAutoQuotaPoolGroupingPolicies(ctx context.Context, ruleNames []string) error {
 	e := casbin.GetEnforcer()
+	if _, err := casbin.TakeSnapshot(ctx, casbin.SnapshotAuto, "", "autoQuotaPool.SyncAutoQuotaPoolGroupingPolicies"); err != nil {
+		return gerror.Wrap(err, "同步自动配额池继承关系前保存规则快照失败")
+	}
 
 	return dao.ConfigAutoQuotaPool.Transaction(ctx, func(txCtx context.Context, _ gdb.TX) error {
 		// 1. 查询自动配额池配置
@@ -53,7 +56,7 @@ func SyncAutoQuotaPoolGroupingPolicies(ctx context.Context, ruleNames []string)
 		existingSubjectsByRole := make(map[string]map[string]struct{}, len(autoRoles))
 		if len(autoRoles) > 0 {
 			for _, role := range autoRoles {
-				users, err := e.GetUsersForRole(role)
+				users, err := e.GetUsersForRole(role, casbin.DefaultDomain)
 				if err != nil {
 					return gerror.Wrapf(err, "查询角色 %s 的用户失败", role)
 				}
@@ -79,24 +82,24 @@ func SyncAutoQuotaPoolGroupingPolicies(ctx context.Context, ruleNames []string)
 			}
 			for subject := range ctx.targetSubjects {
 				if _, ok := existingSubjects[subject]; !ok {
-					policiesToAdd = append(policiesToAdd, []string{subject, role})
+					policiesToAdd = append(policiesToAdd, []string{subject, role, casbin.DefaultDomain})
 				}
 			}
 			for subject := range existingSubjects {
 				if _, ok := ctx.targetSubjects[subject]; !ok {
-					policiesToRemove = append(policiesToRemove, []string{subject, role})
+					policiesToRemove = append(policiesToRemove, []string{subject, role, casbin.DefaultDomain})
 				}
 			}
 		}
 
 		// 5. 批量更新Casbin策略
 		if len(policiesToAdd) > 0 {
-			if _, err := e.AddGroupingPolicies(policiesToAdd); err != nil {
+			if _, err := casbin.AddGroupingPolicies(ctx, "autoQuotaPool.SyncAutoQuotaPoolGroupingPolicies", policiesToAdd, false); err != nil {
 				return gerror.Wrap(err, "批量新增 Casbin 分组失败")
 			}
 		}
 		if len(policiesToRemove) > 0 {
-			if _, err := e.RemoveGroupingPolicies(policiesToRemove); err != nil {
+			if _, err := casbin.RemoveGroupingPolicies(ctx, "autoQuotaPool.SyncAutoQuotaPoolGroupingPolicies", policiesToRemove); err != nil {
 				return gerror.Wrap(err, "批量删除 Casbin 分组失败")
 			}
 		}
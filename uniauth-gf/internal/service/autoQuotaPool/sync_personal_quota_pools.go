@@ -4,8 +4,10 @@ import (
 	"context"
 	"uniauth-gf/internal/dao"
 	"uniauth-gf/internal/model/entity"
+	"uniauth-gf/internal/service/quotaPool"
 
 	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/shopspring/decimal"
@@ -36,6 +38,11 @@ func SyncPersonalQuotaPools(ctx context.Context, ruleName string) error {
 	targetCronCycle := autoQuotaPoolConfig.CronCycle
 	targetRegularQuota := autoQuotaPoolConfig.RegularQuota
 	targetDisabled := !autoQuotaPoolConfig.Enabled
+	targetUserQuotaLimit := autoQuotaPoolConfig.UserQuotaLimit
+	targetUserLimitCronCycle := autoQuotaPoolConfig.UserLimitCronCycle
+	targetRolloverPolicy := autoQuotaPoolConfig.RolloverPolicy
+	targetRolloverCapPercent := autoQuotaPoolConfig.RolloverCapPercent
+	targetRolloverCapAmount := autoQuotaPoolConfig.RolloverCapAmount
 
 	// 查询+更新事务
 	err := dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
@@ -52,6 +59,7 @@ func SyncPersonalQuotaPools(ctx context.Context, ruleName string) error {
 		type quotaPoolUpdate struct {
 			name string
 			data g.Map
+			pool *entity.QuotapoolQuotaPool
 		}
 		updateWithoutRemaining := make([]string, 0)
 		updatesWithRemaining := make([]quotaPoolUpdate, 0)
@@ -69,7 +77,12 @@ func SyncPersonalQuotaPools(ctx context.Context, ruleName string) error {
 
 			baseFieldsChanged := pool.CronCycle != targetCronCycle ||
 				!pool.RegularQuota.Equal(targetRegularQuota) ||
-				pool.Disabled != targetDisabled
+				pool.Disabled != targetDisabled ||
+				!pool.UserQuotaLimit.Equal(targetUserQuotaLimit) ||
+				pool.UserLimitCronCycle != targetUserLimitCronCycle ||
+				pool.RolloverPolicy != targetRolloverPolicy ||
+				!pool.RolloverCapPercent.Equal(targetRolloverCapPercent) ||
+				!pool.RolloverCapAmount.Equal(targetRolloverCapAmount)
 
 			if !pool.Disabled && autoQuotaPoolConfig.Enabled {
 				newRegularQuota := targetRegularQuota
@@ -90,14 +103,20 @@ func SyncPersonalQuotaPools(ctx context.Context, ruleName string) error {
 				// 如果基础字段或剩余配额发生变化，则更新 updateWithRemaining
 				if baseFieldsChanged || remainingChanged {
 					updateData := g.Map{
-						"cron_cycle":      targetCronCycle,
-						"regular_quota":   newRegularQuota,
-						"disabled":        targetDisabled,
-						"remaining_quota": newRemainingQuota,
+						"cron_cycle":            targetCronCycle,
+						"regular_quota":         newRegularQuota,
+						"disabled":              targetDisabled,
+						"remaining_quota":       newRemainingQuota,
+						"user_quota_limit":      targetUserQuotaLimit,
+						"user_limit_cron_cycle": targetUserLimitCronCycle,
+						"rollover_policy":       targetRolloverPolicy,
+						"rollover_cap_percent":  targetRolloverCapPercent,
+						"rollover_cap_amount":   targetRolloverCapAmount,
 					}
 					updatesWithRemaining = append(updatesWithRemaining, quotaPoolUpdate{
 						name: poolName,
 						data: updateData,
+						pool: pool,
 					})
 				}
 				continue
@@ -112,9 +131,14 @@ func SyncPersonalQuotaPools(ctx context.Context, ruleName string) error {
 		// 6. 批量更新个人配额池（不更新剩余配额）
 		if len(updateWithoutRemaining) > 0 {
 			updateData := g.Map{
-				"cron_cycle":    targetCronCycle,
-				"regular_quota": targetRegularQuota,
-				"disabled":      targetDisabled,
+				"cron_cycle":            targetCronCycle,
+				"regular_quota":         targetRegularQuota,
+				"disabled":              targetDisabled,
+				"user_quota_limit":      targetUserQuotaLimit,
+				"user_limit_cron_cycle": targetUserLimitCronCycle,
+				"rollover_policy":       targetRolloverPolicy,
+				"rollover_cap_percent":  targetRolloverCapPercent,
+				"rollover_cap_amount":   targetRolloverCapAmount,
 			}
 
 			if _, err := dao.QuotapoolQuotaPool.Ctx(ctx).
@@ -134,6 +158,21 @@ func SyncPersonalQuotaPools(ctx context.Context, ruleName string) error {
 					Update(); err != nil {
 					return gerror.Wrapf(err, "更新个人配额池失败（更新剩余配额）")
 				}
+				remainingAfter := update.data["remaining_quota"].(decimal.Decimal)
+				if remainingAfter.Equal(update.pool.RemainingQuota) {
+					continue
+				}
+				if err := quotaPool.WriteLedger(ctx, &quotaPool.LedgerEntry{
+					QuotaPoolName:   update.name,
+					Reason:          quotaPool.LedgerRuleSync,
+					RemainingBefore: update.pool.RemainingQuota,
+					RemainingAfter:  remainingAfter,
+					ExtraBefore:     update.pool.ExtraQuota,
+					ExtraAfter:      update.pool.ExtraQuota,
+					Remark:          gjson.New(g.Map{"ruleName": ruleName}),
+				}); err != nil {
+					return err
+				}
 			}
 		}
 		return nil
@@ -0,0 +1,197 @@
+package casbin
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/casbin/govaluate"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// AttributePrefix 属性条件 p 规则的 sub 前缀。sub 为 attr:<条件> 的 p 规则适用于用户信息满足条件的所有用户，例如
+//
+//	p, attr:identityType == Student && residentialCollege == SHAW, default, chat/approach/*, access, allow
+//
+// 条件中的字段为 userinfos_user_infos 的 JSON 字段名（identityType、residentialCollege、department、tags 等），
+// 支持 ==、!=、=~（正则）、&&、||、!、括号，以及 'vip' IN tags。不是字段名的标识符按字符串处理，
+// 值包含空格、点或短横线等符号时需要加引号，例如 schoolStatus == 'In-School'。
+// 条件在鉴权时按缓存的用户信息判定，用户信息修改后最迟在 casbin.attributeCacheTtl（默认 30 秒）后生效，不需要重新同步 g 规则。
+// 没有用户信息的对象（服务、管理员、配额池、角色）不满足任何条件。
+const AttributePrefix = "attr:"
+
+// attributeFields 条件中可以引用的用户信息字段，为 UserinfosUserInfos 的 JSON 字段名
+var attributeFields = func() map[string]struct{} {
+	fields := map[string]struct{}{}
+	for name := range gconv.Map(entity.UserinfosUserInfos{}) {
+		fields[name] = struct{}{}
+	}
+	return fields
+}()
+
+// IsAttributeSubject sub 是否为属性条件
+func IsAttributeSubject(sub string) bool {
+	return strings.HasPrefix(sub, AttributePrefix)
+}
+
+var (
+	conditionMu sync.RWMutex
+	conditions  = map[string]*govaluate.EvaluableExpression{} // 编译后的条件，键为不含前缀的条件
+
+	attributeCache = gcache.New() // 用户信息缓存，键为 UPN，由 PrepareAttributes 写入
+)
+
+// compileCondition 编译条件，条件至少要引用一个用户信息字段，否则多半是字段名写错了
+func compileCondition(condition string) (*govaluate.EvaluableExpression, error) {
+	conditionMu.RLock()
+	expr, ok := conditions[condition]
+	conditionMu.RUnlock()
+	if ok {
+		return expr, nil
+	}
+	expr, err := govaluate.NewEvaluableExpression(condition)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "属性条件 %q 语法错误", condition)
+	}
+	if !slices.ContainsFunc(expr.Vars(), func(name string) bool {
+		_, ok := attributeFields[name]
+		return ok
+	}) {
+		return nil, gerror.Newf("属性条件 %q 没有引用任何用户信息字段，字段名为 userinfos 的 JSON 字段名，例如 identityType", condition)
+	}
+	conditionMu.Lock()
+	conditions[condition] = expr
+	conditionMu.Unlock()
+	return expr, nil
+}
+
+// CheckCondition 检查属性条件 sub（含 attr: 前缀）能否编译
+func CheckCondition(sub string) error {
+	_, err := compileCondition(strings.TrimPrefix(sub, AttributePrefix))
+	return err
+}
+
+// attributeParameters 判定条件时的参数：用户信息字段取用户的值，其他标识符按字符串处理
+type attributeParameters map[string]interface{}
+
+func (p attributeParameters) Get(name string) (interface{}, error) {
+	if _, ok := attributeFields[name]; !ok {
+		return name, nil
+	}
+	return p[name], nil
+}
+
+// attributeLoadBatch PrepareAttributes 每次查询的用户数
+const attributeLoadBatch = 1000
+
+// PrepareAttributes 用请求的 ctx 读取 subs 的用户信息并缓存 casbin.attributeCacheTtl，需要在鉴权之前、拿 Enforcer 锁之前调用。
+// matcher 中的 attributeMatch 只读缓存，不访问数据库；没有预先读取的用户不满足任何属性条件。
+// 已经在缓存中的用户不会重复查询。读取失败时记录日志，这些用户的属性条件规则不生效。
+func PrepareAttributes(ctx context.Context, subs ...string) {
+	var missing []string
+	seen := make(map[string]struct{}, len(subs))
+	for _, sub := range subs {
+		if _, ok := seen[sub]; ok || sub == "" || IsAttributeSubject(sub) {
+			continue
+		}
+		seen[sub] = struct{}{}
+		if ok, _ := attributeCache.Contains(ctx, sub); !ok {
+			missing = append(missing, sub)
+		}
+	}
+	ttl := g.Cfg().MustGetWithEnv(ctx, "casbin.attributeCacheTtl", "30s").Duration()
+	for chunk := range slices.Chunk(missing, attributeLoadBatch) {
+		var infos []*entity.UserinfosUserInfos
+		if err := dao.UserinfosUserInfos.Ctx(ctx).WhereIn(dao.UserinfosUserInfos.Columns().Upn, chunk).Scan(&infos); err != nil {
+			g.Log().Warningf(ctx, "读取 %d 个用户的用户信息失败，这些用户的属性条件规则不生效: %v", len(chunk), err)
+			return
+		}
+		found := make(map[string]attributeParameters, len(infos))
+		for _, info := range infos {
+			found[info.Upn] = toAttributes(info)
+		}
+		for _, upn := range chunk {
+			attributes, ok := found[upn]
+			if !ok {
+				attributes = attributeParameters{}
+			}
+			_ = attributeCache.Set(ctx, upn, attributes, ttl)
+		}
+	}
+}
+
+// toAttributes 把用户信息转换为判定条件时的参数
+func toAttributes(info *entity.UserinfosUserInfos) attributeParameters {
+	attributes := attributeParameters{}
+	for name, value := range gconv.Map(info) {
+		switch value := value.(type) {
+		case []string:
+			// govaluate 的 IN 需要 []interface{}
+			attributes[name] = gconv.Interfaces(value)
+		case *gtime.Time:
+			if value == nil {
+				attributes[name] = ""
+			} else {
+				attributes[name] = value.String()
+			}
+		case nil:
+			attributes[name] = ""
+		default:
+			attributes[name] = value
+		}
+	}
+	return attributes
+}
+
+// AttributeMatch 判断 sub 的用户信息是否满足属性条件 condition（含 attr: 前缀）。condition 不是属性条件时返回 false。
+// 只读取 PrepareAttributes 缓存的用户信息，没有缓存或没有用户信息时返回 false。
+func AttributeMatch(sub, condition string) (bool, error) {
+	if !IsAttributeSubject(condition) || IsAttributeSubject(sub) {
+		return false, nil
+	}
+	expr, err := compileCondition(strings.TrimPrefix(condition, AttributePrefix))
+	if err != nil {
+		return false, err
+	}
+	v, err := attributeCache.Get(context.Background(), sub)
+	if err != nil || v.IsNil() {
+		return false, err
+	}
+	attributes := v.Val().(attributeParameters)
+	if len(attributes) == 0 {
+		return false, nil
+	}
+	res, err := expr.Eval(attributes)
+	if err != nil {
+		return false, gerror.Wrapf(err, "判定 %v 的属性条件 %q 失败", sub, condition)
+	}
+	matched, _ := res.(bool)
+	return matched, nil
+}
+
+// attributeMatchFunc 注册到 Casbin matcher 中的 attributeMatch(r.sub, p.sub)，sub 为 attr:<条件> 的 p 规则按用户信息判定。
+// 条件无法编译或判定出错时只让这一条规则不匹配并记录日志，不影响同一次鉴权中的其他规则。
+func attributeMatchFunc(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, gerror.Newf("attributeMatch 需要 2 个参数，实际有 %d 个", len(args))
+	}
+	sub, ok1 := args[0].(string)
+	condition, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, gerror.New("attributeMatch 的参数必须是字符串")
+	}
+	matched, err := AttributeMatch(sub, condition)
+	if err != nil {
+		g.Log().Warningf(context.Background(), "属性条件规则 %q 不生效: %v", condition, err)
+		return false, nil
+	}
+	return matched, nil
+}
@@ -0,0 +1,83 @@
+package casbin
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gctx"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/operator"
+)
+
+// 审计日志的变更类型
+const (
+	AuditAdd    = "add"
+	AuditRemove = "remove"
+	AuditUpdate = "update"
+)
+
+// 规则类型
+const (
+	PTypePolicy   = "p"
+	PTypeGrouping = "g"
+)
+
+// AuditActorSystem 后台流程修改权限时使用的操作者和来源
+const AuditActorSystem = operator.ActorSystem
+
+// AuditEvent 一次权限变更
+type AuditEvent struct {
+	Action    string
+	PType     string
+	OldRules  [][]string
+	NewRules  [][]string
+	Operation string // 发起变更的业务操作，例如 quotaPool.Edit
+}
+
+// auditContext 推断当前请求的操作者、来源接口和请求 ID。
+//
+// 操作者见 operator.Actor；请求 ID 优先使用 X-Request-Id 请求头，否则使用链路 ID。
+// 没有 HTTP 请求的后台流程，操作者和来源都记为 system。
+func auditContext(ctx context.Context) (actor, source, requestId string) {
+	requestId = gctx.CtxId(ctx)
+	r := g.RequestFromCtx(ctx)
+	if r == nil {
+		return AuditActorSystem, AuditActorSystem, requestId
+	}
+	if id := r.GetHeader("X-Request-Id"); id != "" {
+		requestId = id
+	}
+	return operator.Actor(ctx), r.Method + " " + r.URL.Path, requestId
+}
+
+// RecordAudit 写入一条权限变更审计日志。
+//
+// Casbin 的变更不在业务事务中，审计日志同样不参与调用方的事务，避免业务回滚时丢失已经生效的权限变更记录。
+// 写入失败只记录错误日志，不影响已经生效的变更。
+func RecordAudit(ctx context.Context, event *AuditEvent) {
+	if len(event.OldRules) == 0 && len(event.NewRules) == 0 {
+		return
+	}
+	actor, source, requestId := auditContext(ctx)
+	data := g.Map{
+		"action":     event.Action,
+		"ptype":      event.PType,
+		"actor":      actor,
+		"source":     source,
+		"operation":  event.Operation,
+		"request_id": requestId,
+	}
+	if len(event.OldRules) != 0 {
+		data["old_rules"] = gjson.New(event.OldRules)
+	}
+	if len(event.NewRules) != 0 {
+		data["new_rules"] = gjson.New(event.NewRules)
+	}
+	auditCtx := gdb.WithoutTX(ctx, dao.CasbinAuditLog.Group())
+	if _, err := dao.CasbinAuditLog.Ctx(auditCtx).Data(data).Insert(); err != nil {
+		g.Log().Errorf(ctx, "写入 Casbin 审计日志失败: %v。变更：%+v", err, event)
+	}
+}
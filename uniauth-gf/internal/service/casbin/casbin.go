@@ -15,7 +15,10 @@ import (
 	"github.com/gogf/gf/v2/util/grand"
 )
 
-var e *casbin.Enforcer
+var (
+	e       *casbin.SyncedEnforcer
+	watcher *psqlwatcher.Watcher
+)
 
 func init() {
 	// 从gres中读取Casbin配置文件
@@ -38,6 +41,7 @@ func init() {
 	if err != nil {
 		panic("创建Casbin Watcher失败: " + err.Error())
 	}
+	watcher = w
 
 	// 数据库连接配置
 	dsn := g.Cfg().MustGetWithEnv(ctx, "casbin.default.link")
@@ -47,19 +51,40 @@ func init() {
 		panic("创建Casbin适配器失败: " + err.Error())
 	}
 
+	// 引入域之前的规则需要先迁移到默认域，否则无法按带域的模型加载
+	if _, err := migrateToDomains(ctx, dsn.String(), dbn.String(), a, m); err != nil {
+		panic("迁移Casbin规则到默认域失败: " + err.Error())
+	}
+
 	// 使用model和adapter创建Enforcer
-	e, err = casbin.NewEnforcer(m, a)
+	// 使用带读写锁的 SyncedEnforcer，Watcher 回调和定时任务重新加载规则时不会与鉴权请求竞争
+	e, err = casbin.NewSyncedEnforcer(m, a)
 	if err != nil {
 		panic("创建Casbin Enforcer失败: " + err.Error())
 	}
 
+	// 规则有效期：p 规则通过 matcher 中的 ruleActive 检查，g 规则通过角色管理器检查
+	e.AddFunction("ruleActive", ruleActiveFunc)
+	// 属性条件：sub 为 attr:<条件> 的 p 规则按用户信息判定，见 AttributePrefix
+	e.AddFunction("attributeMatch", attributeMatchFunc)
+	e.SetRoleManager(newValidityRoleManager(10))
+	if err := ReloadValidities(ctx); err != nil {
+		panic("加载Casbin规则有效期失败: " + err.Error())
+	}
+
 	// Watcher
 	// 设置 Watcher
 	if err := e.SetWatcher(w); err != nil {
 		panic("设置Casbin Watcher失败: " + err.Error())
 	}
-	// Watcher 回调处理函数
-	if err := w.SetUpdateCallback(psqlwatcher.DefaultCallback(e)); err != nil {
+	// Watcher 回调处理函数，先重新加载规则有效期，再同步规则
+	callback := psqlwatcher.DefaultCallback(e)
+	if err := w.SetUpdateCallback(func(msg string) {
+		if err := ReloadValidities(ctx); err != nil {
+			g.Log().Error(ctx, "同步Casbin规则有效期失败: "+err.Error())
+		}
+		callback(msg)
+	}); err != nil {
 		panic("设置Casbin Watcher回调处理函数失败: " + err.Error())
 	}
 
@@ -74,6 +99,9 @@ func init() {
 		defer ticker.Stop()
 		for range ticker.C {
 			time.Sleep(time.Duration(grand.Intn(21)) * time.Second)
+			if err := ReloadValidities(ctx); err != nil {
+				g.Log().Error(ctx, "定时加载Casbin规则有效期失败: "+err.Error())
+			}
 			err := e.LoadPolicy()
 			if err != nil {
 				g.Log().Error(ctx, "定时加载Casbin策略失败: "+err.Error())
@@ -82,6 +110,6 @@ func init() {
 	}()
 }
 
-func GetEnforcer() *casbin.Enforcer {
+func GetEnforcer() *casbin.SyncedEnforcer {
 	return e
 }
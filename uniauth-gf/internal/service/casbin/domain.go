@@ -0,0 +1,271 @@
+package casbin
+
+import (
+	"context"
+	"regexp"
+	"slices"
+
+	pgadapter "github.com/casbin/casbin-pg-adapter"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/go-pg/pg/v10"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"uniauth-gf/internal/dao"
+)
+
+// DefaultDomain 默认域。引入域之前的规则都迁移到这个域中，配额池、自动配额池、服务 API Key 和限流规则默认都在这个域中。
+// default 域中的管理员权限对所有域生效，即原有的管理员可以管理所有部门。
+const DefaultDomain = "default"
+
+// Casbin 规则中域字段的位置：p = sub, dom, obj, act, eft；g = 成员, 角色, dom
+const (
+	PolicyDomainIndex   = 1
+	GroupingDomainIndex = 2
+)
+
+// domainPattern 域名称只能包含字母、数字、下划线、短横线和点，避免与 CSV 和规则中的分隔符混淆
+var domainPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]{1,64}$`)
+
+// CheckDomain 检查域名称是否合法
+func CheckDomain(dom string) error {
+	if !domainPattern.MatchString(dom) {
+		return gerror.Newf("域名称 %q 不合法，只能包含字母、数字、下划线、短横线和点，最长 64 个字符", dom)
+	}
+	return nil
+}
+
+// RuleDomain 返回规则所在的域，字段不足时为空
+func RuleDomain(ptype string, rule []string) string {
+	index := PolicyDomainIndex
+	if ptype == PTypeGrouping {
+		index = GroupingDomainIndex
+	}
+	if len(rule) <= index {
+		return ""
+	}
+	return rule[index]
+}
+
+// WithDomain 把不含域的规则（p 为 sub, obj, act, eft，g 为成员, 角色）放到 dom 中，返回 Casbin 中保存的完整规则
+func WithDomain(ptype, dom string, rules [][]string) [][]string {
+	res := make([][]string, len(rules))
+	for i, rule := range rules {
+		if ptype == PTypeGrouping {
+			res[i] = append(slices.Clip(rule), dom)
+		} else if len(rule) > 0 {
+			res[i] = slices.Concat(rule[:1], []string{dom}, rule[1:])
+		} else {
+			res[i] = []string{}
+		}
+	}
+	return res
+}
+
+// IntoDomain 校验管理接口传入的不含域的规则，并放到 dom 中。p 规则需要 sub, obj, act, eft 四个字段，g 规则需要成员、角色两个字段。
+func IntoDomain(ptype, dom string, rules [][]string) ([][]string, error) {
+	if err := CheckDomain(dom); err != nil {
+		return nil, err
+	}
+	size := 4
+	if ptype == PTypeGrouping {
+		size = 2
+	}
+	full := WithDomain(ptype, dom, rules)
+	for i, rule := range rules {
+		if len(rule) != size {
+			return nil, gerror.Newf("第 %d 条 %v 规则 %v 不合法：不含域时需要 %d 个字段，实际有 %d 个", i+1, ptype, rule, size, len(rule))
+		}
+		if problem := validateRule(ptype, full[i]); problem != "" {
+			return nil, gerror.Newf("第 %d 条 %v 规则 %v 不合法：%v", i+1, ptype, rule, problem)
+		}
+	}
+	return full, nil
+}
+
+// WithoutDomain 去掉完整规则中的域字段，与 WithDomain 相反
+func WithoutDomain(ptype string, rules [][]string) [][]string {
+	index := PolicyDomainIndex
+	if ptype == PTypeGrouping {
+		index = GroupingDomainIndex
+	}
+	res := make([][]string, len(rules))
+	for i, rule := range rules {
+		if len(rule) > index {
+			res[i] = slices.Concat(rule[:index], rule[index+1:])
+		} else {
+			res[i] = rule
+		}
+	}
+	return res
+}
+
+// InDomain 返回 rules 中属于 dom 的规则
+func InDomain(ptype, dom string, rules [][]string) [][]string {
+	res := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		if RuleDomain(ptype, rule) == dom {
+			res = append(res, rule)
+		}
+	}
+	return res
+}
+
+// PolicyValuesInDomain 返回 dom 中所有 p 规则第 index 个字段去重后的值，按首次出现的顺序排列
+func PolicyValuesInDomain(dom string, index int) ([]string, error) {
+	policies, err := e.GetFilteredPolicy(PolicyDomainIndex, dom)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "查询域 %v 的规则失败", dom)
+	}
+	values := make([]string, 0, len(policies))
+	seen := make(map[string]struct{}, len(policies))
+	for _, rule := range policies {
+		if _, ok := seen[rule[index]]; !ok {
+			seen[rule[index]] = struct{}{}
+			values = append(values, rule[index])
+		}
+	}
+	return values, nil
+}
+
+// GetAllRolesInDomain 返回 dom 中所有 g 规则的角色，按首次出现的顺序排列
+func GetAllRolesInDomain(dom string) ([]string, error) {
+	groupings, err := e.GetFilteredGroupingPolicy(GroupingDomainIndex, dom)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "查询域 %v 的角色继承关系失败", dom)
+	}
+	roles := make([]string, 0, len(groupings))
+	seen := make(map[string]struct{}, len(groupings))
+	for _, rule := range groupings {
+		if _, ok := seen[rule[1]]; !ok {
+			seen[rule[1]] = struct{}{}
+			roles = append(roles, rule[1])
+		}
+	}
+	return roles, nil
+}
+
+// GetAllDomains 返回所有规则中出现过的域，按名称排序，总是包含默认域
+func GetAllDomains() ([]string, error) {
+	policies, groupings, err := liveRules()
+	if err != nil {
+		return nil, err
+	}
+	domains := []string{DefaultDomain}
+	for _, rule := range policies {
+		domains = append(domains, RuleDomain(PTypePolicy, rule))
+	}
+	for _, rule := range groupings {
+		domains = append(domains, RuleDomain(PTypeGrouping, rule))
+	}
+	slices.Sort(domains)
+	return slices.DeleteFunc(slices.Compact(domains), func(dom string) bool { return dom == "" }), nil
+}
+
+// 迁移到域时使用的 Postgres advisory lock 键，多个实例同时启动时只有一个实例执行迁移
+const domainMigrationLockKey int64 = 0x6361_7362_696e_646d
+
+// 规则有效期和快照中的规则是 JSON，直接用 SQL 迁移
+const (
+	migrateValiditySql = `UPDATE casbin_rule_validity SET rule = CASE
+	WHEN ptype = 'p' AND jsonb_array_length(rule) = 4 THEN jsonb_build_array(rule -> 0, ?::text, rule -> 1, rule -> 2, rule -> 3)
+	WHEN ptype = 'g' AND jsonb_array_length(rule) = 2 THEN rule || jsonb_build_array(?::text)
+	ELSE rule END`
+	migrateSnapshotSql = `UPDATE casbin_policy_snapshot SET
+	policies = COALESCE((SELECT jsonb_agg(CASE WHEN jsonb_array_length(r) = 4
+		THEN jsonb_build_array(r -> 0, ?::text, r -> 1, r -> 2, r -> 3) ELSE r END ORDER BY i)
+		FROM jsonb_array_elements(policies) WITH ORDINALITY AS t(r, i)), '[]'::jsonb),
+	groupings = COALESCE((SELECT jsonb_agg(CASE WHEN jsonb_array_length(r) = 2
+		THEN r || jsonb_build_array(?::text) ELSE r END ORDER BY i)
+		FROM jsonb_array_elements(groupings) WITH ORDINALITY AS t(r, i)), '[]'::jsonb)`
+)
+
+// migrateToDomains 把引入域之前的规则迁移到默认域：p 规则 sub, obj, act, eft 变为 sub, default, obj, act, eft，
+// g 规则 成员, 角色 变为 成员, 角色, default。规则有效期和快照中的规则一起迁移，审计日志保留原样。
+//
+// 启动时在加载规则之前调用，m 为带域的模型，link 和 database 与创建适配器时相同。
+// 已经迁移过的规则不会改变，没有需要迁移的规则时什么也不做。
+// Casbin 按模型校验规则的字段个数，无法加载旧格式的规则，所以直接读取规则表；
+// 规则的主键由适配器根据规则内容计算，所以通过适配器整体重写规则，而不是在数据库中直接修改字段。
+func migrateToDomains(ctx context.Context, link, database string, a persist.Adapter, m model.Model) (migrated int, err error) {
+	err = dao.CasbinRuleValidity.Transaction(ctx, func(txCtx context.Context, tx gdb.TX) error {
+		if _, err := tx.Exec("SELECT pg_advisory_xact_lock(?)", domainMigrationLockKey); err != nil {
+			return gerror.Wrap(err, "获取域迁移锁失败")
+		}
+
+		policies, groupings, err := loadRawRules(link, database)
+		if err != nil {
+			return err
+		}
+		for i, rule := range policies {
+			if len(rule) == 4 {
+				policies[i] = WithDomain(PTypePolicy, DefaultDomain, [][]string{rule})[0]
+				migrated++
+			}
+		}
+		for i, rule := range groupings {
+			if len(rule) == 2 {
+				groupings[i] = WithDomain(PTypeGrouping, DefaultDomain, [][]string{rule})[0]
+				migrated++
+			}
+		}
+		if migrated == 0 {
+			return nil
+		}
+
+		// 先迁移有效期和快照，最后重写规则。中途失败时规则仍是旧格式，下次启动会重新迁移
+		if _, err = tx.Exec(migrateValiditySql, DefaultDomain, DefaultDomain); err != nil {
+			return gerror.Wrap(err, "迁移规则有效期失败")
+		}
+		if _, err = tx.Exec(migrateSnapshotSql, DefaultDomain, DefaultDomain); err != nil {
+			return gerror.Wrap(err, "迁移规则快照失败")
+		}
+		target := m.Copy()
+		target.ClearPolicy()
+		if err = target.AddPolicies("p", PTypePolicy, policies); err != nil {
+			return gerror.Wrap(err, "构造迁移后的 p 规则失败")
+		}
+		if err = target.AddPolicies("g", PTypeGrouping, groupings); err != nil {
+			return gerror.Wrap(err, "构造迁移后的 g 规则失败")
+		}
+		if err = a.SavePolicy(target); err != nil {
+			return gerror.Wrap(err, "写入迁移后的规则失败")
+		}
+		g.Log().Infof(ctx, "已将 %d 条没有域的 Casbin 规则迁移到 %v 域", migrated, DefaultDomain)
+		return nil
+	})
+	return
+}
+
+// loadRawRules 直接读取规则表中的全部规则，不按模型校验字段个数，末尾的空字段会被去掉
+func loadRawRules(link, database string) (policies, groupings [][]string, err error) {
+	opts, err := pg.ParseURL(link)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "解析 Casbin 数据库连接失败")
+	}
+	if database != "" {
+		opts.Database = database
+	}
+	db := pg.Connect(opts)
+	defer db.Close()
+
+	var lines []*pgadapter.CasbinRule
+	if err = db.Model(&lines).Table(pgadapter.DefaultTableName).Select(); err != nil {
+		return nil, nil, gerror.Wrap(err, "读取原始规则失败")
+	}
+	for _, line := range lines {
+		rule := []string{line.V0, line.V1, line.V2, line.V3, line.V4, line.V5}
+		for len(rule) > 0 && rule[len(rule)-1] == "" {
+			rule = rule[:len(rule)-1]
+		}
+		switch line.Ptype {
+		case PTypePolicy:
+			policies = append(policies, rule)
+		case PTypeGrouping:
+			groupings = append(groupings, rule)
+		}
+	}
+	return
+}
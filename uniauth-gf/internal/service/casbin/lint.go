@@ -0,0 +1,576 @@
+package casbin
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v2/util"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/internal/dao"
+)
+
+// 检查结果的类型
+const (
+	LintDuplicate      = "duplicate"      // 重复的规则
+	LintUnreachable    = "unreachable"    // 永远不会生效的规则
+	LintShadowed       = "shadowed"       // 被更宽的 deny 规则遮蔽的 allow 规则
+	LintRoleCycle      = "roleCycle"      // 角色循环继承
+	LintEmptyRole      = "emptyRole"      // 继承的角色及其上级角色都没有 p 规则
+	LintUnknownSubject = "unknownSubject" // 不是角色、配额池，也不在 userinfos_user_infos 中的对象
+)
+
+// 检查结果的严重程度
+const (
+	LintError   = "error"   // 规则没有按预期生效，需要处理
+	LintWarning = "warning" // 很可能是配置错误
+	LintInfo    = "info"    // 不影响鉴权结果，可以清理
+)
+
+// lintSamples 问题中列出的受影响对象的最大个数
+const lintSamples = 10
+
+// LintIssue 一条检查结果
+type LintIssue struct {
+	Kind     string     `json:"kind"`
+	Severity string     `json:"severity"`
+	PType    string     `json:"ptype"`   // 问题所在规则的类型，与具体规则无关时为空
+	Rule     []string   `json:"rule"`    // 问题所在的规则
+	Related  [][]string `json:"related"` // 造成问题的其他规则，例如遮蔽 allow 规则的 deny 规则、组成循环的 g 规则
+	Message  string     `json:"message"`
+}
+
+// LintReport 规则检查报告
+type LintReport struct {
+	Matcher       string       // 分析时使用的 matcher
+	PolicyCount   int          // 分析的 p 规则条数
+	GroupingCount int          // 分析的 g 规则条数
+	Issues        []*LintIssue // 按规则类型和规则顺序排列
+}
+
+// Counts 按类型统计问题条数
+func (r *LintReport) Counts() map[string]int {
+	counts := map[string]int{}
+	for _, issue := range r.Issues {
+		counts[issue.Kind]++
+	}
+	return counts
+}
+
+// objMatcherPattern 从 matcher 中找出比较 r.obj 和 p.obj 的函数，例如 keyMatch(r.obj, p.obj)
+var objMatcherPattern = regexp.MustCompile(`(\w+)\(\s*r\.obj\s*,\s*p\.obj\s*\)`)
+
+// objMatcher 根据 matcher 返回 obj 的匹配函数，以及判断一个 obj 模式是否覆盖另一个 obj 模式的函数。
+// 无法识别时返回 nil，不做遮蔽检查。
+func objMatcher(matcher string) (match func(key, pattern string) bool, covers func(broad, narrow string) bool) {
+	name := ""
+	if m := objMatcherPattern.FindStringSubmatch(matcher); m != nil {
+		name = m[1]
+	} else if strings.Contains(matcher, "r.obj == p.obj") {
+		name = "equal"
+	}
+	// prefix 为 "/*" 或 "*" 结尾的模式可以匹配的前缀，其他模式为空
+	var prefix func(pattern string) (string, bool)
+	switch name {
+	case "equal":
+		match = func(key, pattern string) bool { return key == pattern }
+		prefix = func(string) (string, bool) { return "", false }
+	case "keyMatch":
+		match = util.KeyMatch
+		prefix = func(pattern string) (string, bool) {
+			i := strings.Index(pattern, "*")
+			return pattern[:max(i, 0)], i >= 0
+		}
+	case "keyMatch2":
+		match = util.KeyMatch2
+		prefix = func(pattern string) (string, bool) {
+			if p, ok := strings.CutSuffix(pattern, "/*"); ok && !hasWildcard(p) {
+				return p + "/", true
+			}
+			return "", false
+		}
+	default:
+		return nil, nil
+	}
+	covers = func(broad, narrow string) bool {
+		if broad == narrow || (!hasWildcard(narrow) && match(narrow, broad)) {
+			return true
+		}
+		// broad 是前缀匹配时，narrow 通配符之前的固定部分以该前缀开头即被覆盖
+		p, ok := prefix(broad)
+		return ok && strings.HasPrefix(literalPrefix(narrow), p)
+	}
+	return match, covers
+}
+
+// hasWildcard 判断 obj 模式中是否有通配符或路径参数
+func hasWildcard(pattern string) bool {
+	return strings.ContainsAny(pattern, "*{") || strings.Contains(pattern, "/:")
+}
+
+// literalPrefix 返回 obj 模式中第一个通配符之前的固定部分
+func literalPrefix(pattern string) string {
+	end := len(pattern)
+	for _, sep := range []string{"*", "{", ":"} {
+		if i := strings.Index(pattern, sep); i >= 0 && i < end {
+			end = i
+		}
+	}
+	return pattern[:end]
+}
+
+// Lint 检查当前加载的模型和规则，返回可能的配置问题：
+//   - 重复的规则，包括只有首尾空白不同的规则；
+//   - 永远不会生效的规则：eft 不是 allow 或 deny、有效期已经结束、属性条件无法编译、g 规则中出现属性条件；
+//   - 被 deny 规则遮蔽的 allow 规则：deny 规则覆盖 allow 规则的 obj 和 act，且适用于 allow 规则的全部或部分对象。
+//     全部遮蔽时 allow 规则不会生效，部分遮蔽时列出受影响的对象；
+//   - 角色循环继承；
+//   - 继承的角色及其上级角色都没有 p 规则的 g 规则，按角色汇总；
+//   - 既不是角色、配额池，也不在 userinfos_user_infos 中的对象。admin: 和 svc: 开头的管理员和服务对象、attr: 开头的属性条件不检查。
+//
+// 角色继承只在同一个域内生效，遮蔽、循环和空角色都按域分别分析。
+// 对象适用的范围按当前有效的 g 规则计算，obj 的匹配方式从 matcher 中识别，目前支持 keyMatch、keyMatch2 和相等比较。
+func Lint(ctx context.Context) (*LintReport, error) {
+	// 在同一个读锁下取出模型和规则，保证分析基于同一份数据
+	lock := e.GetLock()
+	lock.RLock()
+	matcher := ""
+	if ast, ok := e.Enforcer.GetModel()["m"]["m"]; ok {
+		matcher = ast.Value
+	}
+	policies, err := e.Enforcer.GetPolicy()
+	if err != nil {
+		lock.RUnlock()
+		return nil, gerror.Wrap(err, "读取当前 p 规则失败")
+	}
+	groupings, err := e.Enforcer.GetGroupingPolicy()
+	lock.RUnlock()
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取当前 g 规则失败")
+	}
+
+	report := &LintReport{
+		Matcher:       matcher,
+		PolicyCount:   len(policies),
+		GroupingCount: len(groupings),
+		Issues:        []*LintIssue{},
+	}
+	l := newLinter(policies, groupings)
+	report.Issues = append(report.Issues, l.duplicates()...)
+	report.Issues = append(report.Issues, l.unreachable()...)
+	if _, covers := objMatcher(matcher); covers != nil && strings.Contains(matcher, "r.act == p.act") {
+		report.Issues = append(report.Issues, l.shadowed(covers)...)
+	} else {
+		report.Issues = append(report.Issues, &LintIssue{
+			Kind:     LintShadowed,
+			Severity: LintInfo,
+			Message:  fmt.Sprintf("无法识别 matcher 中 obj 和 act 的匹配方式，没有检查遮蔽：%v", matcher),
+		})
+	}
+	report.Issues = append(report.Issues, l.roleCycles()...)
+	report.Issues = append(report.Issues, l.emptyRoles()...)
+	unknown, err := l.unknownSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Issues = append(report.Issues, unknown...)
+	return report, nil
+}
+
+// node 某个域中的对象或角色。角色继承只在同一个域内生效，不同域中的同名角色互不相关。
+type node struct {
+	name string
+	dom  string
+}
+
+// linter 一次检查使用的规则和由 g 规则建立的继承关系
+type linter struct {
+	policies  [][]string
+	groupings [][]string
+	now       time.Time
+	roles     map[node][]node // 成员直接继承的角色，只含有效期内的 g 规则
+	members   map[node][]node // 角色的直接成员，只含有效期内的 g 规则
+	bySub     map[node][][]string
+}
+
+func newLinter(policies, groupings [][]string) *linter {
+	l := &linter{
+		policies:  policies,
+		groupings: groupings,
+		now:       time.Now(),
+		roles:     map[node][]node{},
+		members:   map[node][]node{},
+		bySub:     map[node][][]string{},
+	}
+	for _, rule := range groupings {
+		if len(rule) < 3 || !RuleActive(PTypeGrouping, rule) {
+			continue
+		}
+		member, role := node{rule[0], rule[2]}, node{rule[1], rule[2]}
+		l.roles[member] = append(l.roles[member], role)
+		l.members[role] = append(l.members[role], member)
+	}
+	for _, rule := range policies {
+		if len(rule) > 1 {
+			sub := node{rule[0], rule[1]}
+			l.bySub[sub] = append(l.bySub[sub], rule)
+		}
+	}
+	return l
+}
+
+// reach 返回从 start 出发沿 next 能到达的所有节点，包含 start 本身。循环继承时每个节点只访问一次。
+func reach(start node, next map[node][]node) map[node]struct{} {
+	seen := map[node]struct{}{start: {}}
+	for queue := []node{start}; len(queue) > 0; queue = queue[1:] {
+		for _, n := range next[queue[0]] {
+			if _, ok := seen[n]; !ok {
+				seen[n] = struct{}{}
+				queue = append(queue, n)
+			}
+		}
+	}
+	return seen
+}
+
+func (l *linter) duplicates() []*LintIssue {
+	var issues []*LintIssue
+	for _, section := range []struct {
+		ptype string
+		rules [][]string
+	}{{PTypePolicy, l.policies}, {PTypeGrouping, l.groupings}} {
+		first := map[string][]string{}
+		for _, rule := range section.rules {
+			trimmed := make([]string, len(rule))
+			for i, field := range rule {
+				trimmed[i] = strings.TrimSpace(field)
+			}
+			key := ruleKey(section.ptype, trimmed)
+			if prev, ok := first[key]; ok {
+				issues = append(issues, &LintIssue{
+					Kind:     LintDuplicate,
+					Severity: LintInfo,
+					PType:    section.ptype,
+					Rule:     rule,
+					Related:  [][]string{prev},
+					Message:  "与另一条规则重复（忽略首尾空白），可以删除",
+				})
+				continue
+			}
+			first[key] = rule
+		}
+	}
+	return issues
+}
+
+func (l *linter) unreachable() []*LintIssue {
+	var issues []*LintIssue
+	expired := func(ptype string, rule []string) bool {
+		validityMu.RLock()
+		v, ok := validities[ruleKey(ptype, rule)]
+		validityMu.RUnlock()
+		return ok && v.Until != nil && !l.now.Before(v.Until.Time)
+	}
+	for _, rule := range l.policies {
+		switch {
+		case len(rule) < 5:
+			issues = append(issues, &LintIssue{
+				Kind: LintUnreachable, Severity: LintError, PType: PTypePolicy, Rule: rule,
+				Message: fmt.Sprintf("p 规则需要 sub, dom, obj, act, eft 五个字段，实际有 %d 个", len(rule)),
+			})
+		case rule[4] != "allow" && rule[4] != "deny":
+			issues = append(issues, &LintIssue{
+				Kind: LintUnreachable, Severity: LintError, PType: PTypePolicy, Rule: rule,
+				Message: fmt.Sprintf("eft 为 %v，既不是 allow 也不是 deny，不会参与鉴权", rule[4]),
+			})
+		case IsAttributeSubject(rule[0]) && CheckCondition(rule[0]) != nil:
+			issues = append(issues, &LintIssue{
+				Kind: LintUnreachable, Severity: LintError, PType: PTypePolicy, Rule: rule,
+				Message: fmt.Sprintf("属性条件无法判定，鉴权时会返回错误：%v", CheckCondition(rule[0])),
+			})
+		case expired(PTypePolicy, rule):
+			issues = append(issues, &LintIssue{
+				Kind: LintUnreachable, Severity: LintWarning, PType: PTypePolicy, Rule: rule,
+				Message: "有效期已经结束，不会再生效，等待过期规则清理任务删除",
+			})
+		}
+	}
+	for _, rule := range l.groupings {
+		if len(rule) < 3 {
+			issues = append(issues, &LintIssue{
+				Kind: LintUnreachable, Severity: LintError, PType: PTypeGrouping, Rule: rule,
+				Message: fmt.Sprintf("g 规则需要成员、角色和 dom 三个字段，实际有 %d 个", len(rule)),
+			})
+		} else if IsAttributeSubject(rule[0]) || IsAttributeSubject(rule[1]) {
+			issues = append(issues, &LintIssue{
+				Kind: LintUnreachable, Severity: LintError, PType: PTypeGrouping, Rule: rule,
+				Message: "属性条件只能作为 p 规则的 sub，g 规则中的属性条件不会匹配任何请求",
+			})
+		} else if expired(PTypeGrouping, rule) {
+			issues = append(issues, &LintIssue{
+				Kind: LintUnreachable, Severity: LintWarning, PType: PTypeGrouping, Rule: rule,
+				Message: "有效期已经结束，继承关系不会再生效，等待过期规则清理任务删除",
+			})
+		}
+	}
+	return issues
+}
+
+// shadowed 找出被 deny 规则遮蔽的 allow 规则。
+//
+// 请求的对象 r.sub 满足 g(r.sub, p.sub) 时规则才适用，所以规则适用于 p.sub 本身以及直接或间接继承它的所有成员。
+// deny 规则覆盖 allow 规则的 obj、act 相同，并且适用于 allow 规则适用的对象时，这些对象的 allow 规则被遮蔽。
+// 属性条件规则适用的用户取决于用户信息，只当作一个对象，与 sub 相同的规则比较。
+func (l *linter) shadowed(covers func(broad, narrow string) bool) []*LintIssue {
+	var denies [][]string
+	for _, rule := range l.policies {
+		if len(rule) >= 5 && rule[4] == "deny" && RuleActive(PTypePolicy, rule) {
+			denies = append(denies, rule)
+		}
+	}
+	if len(denies) == 0 {
+		return nil
+	}
+
+	subjects := map[node]map[node]struct{}{}
+	subjectsOf := func(sub node) map[node]struct{} {
+		if s, ok := subjects[sub]; ok {
+			return s
+		}
+		subjects[sub] = reach(sub, l.members)
+		return subjects[sub]
+	}
+
+	var issues []*LintIssue
+	for _, allow := range l.policies {
+		if len(allow) < 5 || allow[4] != "allow" || !RuleActive(PTypePolicy, allow) {
+			continue
+		}
+		var related [][]string
+		affected := map[node]struct{}{}
+		allowSubjects := subjectsOf(node{allow[0], allow[1]})
+		for _, deny := range denies {
+			if deny[1] != allow[1] || deny[3] != allow[3] || !covers(deny[2], allow[2]) {
+				continue
+			}
+			denySubjects := subjectsOf(node{deny[0], deny[1]})
+			hit := false
+			for sub := range allowSubjects {
+				if _, ok := denySubjects[sub]; ok {
+					affected[sub] = struct{}{}
+					hit = true
+				}
+			}
+			if hit {
+				related = append(related, deny)
+			}
+		}
+		if len(affected) == 0 {
+			continue
+		}
+		issue := &LintIssue{Kind: LintShadowed, PType: PTypePolicy, Rule: allow, Related: related}
+		if len(affected) == len(allowSubjects) {
+			issue.Severity = LintError
+			issue.Message = fmt.Sprintf("适用的 %d 个对象全部被 deny 规则遮蔽，这条 allow 规则不会生效", len(affected))
+		} else {
+			names := make([]string, 0, len(affected))
+			for sub := range affected {
+				names = append(names, sub.name)
+			}
+			slices.Sort(names)
+			issue.Severity = LintWarning
+			issue.Message = fmt.Sprintf("适用的 %d 个对象中有 %d 个被 deny 规则遮蔽：%v",
+				len(allowSubjects), len(affected), strings.Join(names[:min(len(names), lintSamples)], ", "))
+			if len(names) > lintSamples {
+				issue.Message += " 等"
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// roleCycles 找出 g 规则中的循环继承，每个循环只报告一次。
+// 循环继承不会让鉴权出错，但角色管理器展开到最大层数才会停止，通常是误操作。
+func (l *linter) roleCycles() []*LintIssue {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[node]int{}
+	seen := map[string]struct{}{}
+	var (
+		issues []*LintIssue
+		path   []node
+		visit  func(n node)
+	)
+	visit = func(n node) {
+		state[n] = visiting
+		path = append(path, n)
+		for _, role := range l.roles[n] {
+			switch state[role] {
+			case 0:
+				visit(role)
+			case visiting:
+				// 同一个循环中的节点都在同一个域
+				cycle := make([]string, 0, len(path))
+				for _, member := range path[slices.Index(path, role):] {
+					cycle = append(cycle, member.name)
+				}
+				// 以字典序最小的名字开头，作为循环的唯一标识
+				start := slices.Index(cycle, slices.Min(cycle))
+				cycle = append(append([]string{}, cycle[start:]...), cycle[:start]...)
+				key := role.dom + "\x1f" + strings.Join(cycle, "\x1f")
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				related := make([][]string, len(cycle))
+				for i, member := range cycle {
+					related[i] = []string{member, cycle[(i+1)%len(cycle)], role.dom}
+				}
+				issues = append(issues, &LintIssue{
+					Kind:     LintRoleCycle,
+					Severity: LintError,
+					PType:    PTypeGrouping,
+					Rule:     related[len(related)-1],
+					Related:  related,
+					Message:  fmt.Sprintf("域 %v 中角色循环继承：%v -> %v", role.dom, strings.Join(cycle, " -> "), cycle[0]),
+				})
+			}
+		}
+		path = path[:len(path)-1]
+		state[n] = done
+	}
+
+	nodes := make([]node, 0, len(l.roles))
+	for n := range l.roles {
+		nodes = append(nodes, n)
+	}
+	slices.SortFunc(nodes, func(a, b node) int {
+		if c := strings.Compare(a.dom, b.dom); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	for _, n := range nodes {
+		if state[n] == 0 {
+			visit(n)
+		}
+	}
+	return issues
+}
+
+// emptyRoles 找出自身及上级角色都没有 p 规则的角色，继承这些角色不会得到任何权限。
+// 配额池的角色继承也会被检查：没有任何 p 规则的配额池无法使用任何服务。
+func (l *linter) emptyRoles() []*LintIssue {
+	var (
+		issues  []*LintIssue
+		checked = map[node]struct{}{}
+	)
+	for _, rule := range l.groupings {
+		if len(rule) < 3 {
+			continue
+		}
+		role := node{rule[1], rule[2]}
+		if _, ok := checked[role]; ok {
+			continue
+		}
+		checked[role] = struct{}{}
+		empty := true
+		for n := range reach(role, l.roles) {
+			if len(l.bySub[n]) > 0 {
+				empty = false
+				break
+			}
+		}
+		if !empty {
+			continue
+		}
+		members := l.members[role]
+		issues = append(issues, &LintIssue{
+			Kind:     LintEmptyRole,
+			Severity: LintWarning,
+			PType:    PTypeGrouping,
+			Rule:     rule,
+			Message:  fmt.Sprintf("域 %v 中角色 %v 及其继承的角色都没有 p 规则，%d 个成员继承它不会得到任何权限", role.dom, role.name, len(members)),
+		})
+	}
+	return issues
+}
+
+// unknownSubjects 找出 userinfos_user_infos 中没有的对象。
+// 在规则所在的域中被其他对象继承的名字是角色，配额池名称、admin: 和 svc: 开头的对象以及 attr: 开头的属性条件也不是用户，都不检查。
+func (l *linter) unknownSubjects(ctx context.Context) ([]*LintIssue, error) {
+	rules := map[string][]string{} // 对象第一次出现的规则
+	ptypes := map[string]string{}
+	counts := map[string][2]int{} // 对象在所有域中的 p 规则条数和继承的角色个数
+	var names []string
+	add := func(ptype string, rule []string) {
+		name := rule[0]
+		c := counts[name]
+		if ptype == PTypePolicy {
+			c[0]++
+		} else {
+			c[1]++
+		}
+		counts[name] = c
+		if _, ok := rules[name]; ok {
+			return
+		}
+		if _, isRole := l.members[node{name, RuleDomain(ptype, rule)}]; isRole || strings.HasPrefix(name, "admin:") || strings.HasPrefix(name, "svc:") || IsAttributeSubject(name) {
+			return
+		}
+		rules[name], ptypes[name] = rule, ptype
+		names = append(names, name)
+	}
+	for _, rule := range l.policies {
+		if len(rule) > 1 {
+			add(PTypePolicy, rule)
+		}
+	}
+	for _, rule := range l.groupings {
+		if len(rule) > 2 {
+			add(PTypeGrouping, rule)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	known := map[string]struct{}{}
+	const chunk = 1000
+	for start := 0; start < len(names); start += chunk {
+		batch := names[start:min(start+chunk, len(names))]
+		upns, err := dao.UserinfosUserInfos.Ctx(ctx).Fields("upn").WhereIn("upn", batch).Array()
+		if err != nil {
+			return nil, gerror.Wrap(err, "查询用户信息失败")
+		}
+		pools, err := dao.QuotapoolQuotaPool.Ctx(ctx).Fields("quota_pool_name").WhereIn("quota_pool_name", batch).Array()
+		if err != nil {
+			return nil, gerror.Wrap(err, "查询配额池失败")
+		}
+		for _, v := range append(upns, pools...) {
+			known[v.String()] = struct{}{}
+		}
+	}
+
+	var issues []*LintIssue
+	for _, name := range names {
+		if _, ok := known[name]; ok {
+			continue
+		}
+		issues = append(issues, &LintIssue{
+			Kind:     LintUnknownSubject,
+			Severity: LintWarning,
+			PType:    ptypes[name],
+			Rule:     rules[name],
+			Message:  fmt.Sprintf("%v 不是角色或配额池，也不在用户信息中，可能是已经离开的用户或拼写错误，共 %d 条 p 规则、%d 个角色", name, counts[name][0], counts[name][1]),
+		})
+	}
+	return issues, nil
+}
@@ -0,0 +1,147 @@
+package casbin
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// 以下函数在修改 Casbin 规则的同时写入审计日志，所有权限变更都应该通过它们完成。
+// operation 为发起变更的业务操作，例如 quotaPool.Edit，会记录在审计日志中。
+
+// AddPolicies 批量添加 p 规则。skip 为 true 时跳过已经存在的规则，否则有任一规则已存在时不做任何修改。
+// 返回实际添加的规则。
+func AddPolicies(ctx context.Context, operation string, rules [][]string, skip bool) (added [][]string, err error) {
+	return addRules(ctx, operation, PTypePolicy, rules, skip, Validity{})
+}
+
+// AddPoliciesWithin 批量添加只在有效期内生效的 p 规则，有效期只作用于实际添加的规则，语义同 AddPolicies。
+func AddPoliciesWithin(ctx context.Context, operation string, rules [][]string, skip bool, validity Validity) (added [][]string, err error) {
+	return addRules(ctx, operation, PTypePolicy, rules, skip, validity)
+}
+
+// AddGroupingPolicies 批量添加 g 规则，语义同 AddPolicies。
+func AddGroupingPolicies(ctx context.Context, operation string, rules [][]string, skip bool) (added [][]string, err error) {
+	return addRules(ctx, operation, PTypeGrouping, rules, skip, Validity{})
+}
+
+// AddGroupingPoliciesWithin 批量添加只在有效期内生效的 g 规则，语义同 AddPoliciesWithin。
+func AddGroupingPoliciesWithin(ctx context.Context, operation string, rules [][]string, skip bool, validity Validity) (added [][]string, err error) {
+	return addRules(ctx, operation, PTypeGrouping, rules, skip, validity)
+}
+
+// RemovePolicies 批量删除 p 规则。有任一规则不存在时不做任何修改，ok 为 false。
+func RemovePolicies(ctx context.Context, operation string, rules [][]string) (ok bool, err error) {
+	if ok, err = e.RemovePolicies(rules); err != nil || !ok {
+		return
+	}
+	RecordAudit(ctx, &AuditEvent{Action: AuditRemove, PType: PTypePolicy, OldRules: rules, Operation: operation})
+	dropValidity(ctx, PTypePolicy, rules)
+	return
+}
+
+// RemoveGroupingPolicies 批量删除 g 规则，语义同 RemovePolicies。
+func RemoveGroupingPolicies(ctx context.Context, operation string, rules [][]string) (ok bool, err error) {
+	if ok, err = e.RemoveGroupingPolicies(rules); err != nil || !ok {
+		return
+	}
+	RecordAudit(ctx, &AuditEvent{Action: AuditRemove, PType: PTypeGrouping, OldRules: rules, Operation: operation})
+	dropValidity(ctx, PTypeGrouping, rules)
+	return
+}
+
+// RemoveFilteredPolicy 删除第 fieldIndex 个字段起与 fieldValues 匹配的所有 p 规则，返回被删除的规则。
+func RemoveFilteredPolicy(ctx context.Context, operation string, fieldIndex int, fieldValues ...string) (removed [][]string, err error) {
+	if removed, err = e.GetFilteredPolicy(fieldIndex, fieldValues...); err != nil || len(removed) == 0 {
+		return nil, err
+	}
+	if _, err = e.RemoveFilteredPolicy(fieldIndex, fieldValues...); err != nil {
+		return nil, err
+	}
+	RecordAudit(ctx, &AuditEvent{Action: AuditRemove, PType: PTypePolicy, OldRules: removed, Operation: operation})
+	dropValidity(ctx, PTypePolicy, removed)
+	return
+}
+
+// RemoveFilteredGroupingPolicy 删除第 fieldIndex 个字段起与 fieldValues 匹配的所有 g 规则，返回被删除的规则。
+func RemoveFilteredGroupingPolicy(ctx context.Context, operation string, fieldIndex int, fieldValues ...string) (removed [][]string, err error) {
+	if removed, err = e.GetFilteredGroupingPolicy(fieldIndex, fieldValues...); err != nil || len(removed) == 0 {
+		return nil, err
+	}
+	if _, err = e.RemoveFilteredGroupingPolicy(fieldIndex, fieldValues...); err != nil {
+		return nil, err
+	}
+	RecordAudit(ctx, &AuditEvent{Action: AuditRemove, PType: PTypeGrouping, OldRules: removed, Operation: operation})
+	dropValidity(ctx, PTypeGrouping, removed)
+	return
+}
+
+// UpdatePolicy 把一条 p 规则修改为新规则。旧规则不存在时 ok 为 false。
+func UpdatePolicy(ctx context.Context, operation string, oldRule, newRule []string) (ok bool, err error) {
+	if ok, err = e.UpdatePolicy(oldRule, newRule); err != nil || !ok {
+		return
+	}
+	RecordAudit(ctx, &AuditEvent{Action: AuditUpdate, PType: PTypePolicy, OldRules: [][]string{oldRule}, NewRules: [][]string{newRule}, Operation: operation})
+	dropValidity(ctx, PTypePolicy, [][]string{oldRule})
+	return
+}
+
+// UpdateGroupingPolicy 把一条 g 规则修改为新规则，语义同 UpdatePolicy。
+func UpdateGroupingPolicy(ctx context.Context, operation string, oldRule, newRule []string) (ok bool, err error) {
+	if ok, err = e.UpdateGroupingPolicy(oldRule, newRule); err != nil || !ok {
+		return
+	}
+	RecordAudit(ctx, &AuditEvent{Action: AuditUpdate, PType: PTypeGrouping, OldRules: [][]string{oldRule}, NewRules: [][]string{newRule}, Operation: operation})
+	dropValidity(ctx, PTypeGrouping, [][]string{oldRule})
+	return
+}
+
+func addRules(ctx context.Context, operation, ptype string, rules [][]string, skip bool, validity Validity) (added [][]string, err error) {
+	has := e.HasPolicy
+	if ptype == PTypeGrouping {
+		has = e.HasGroupingPolicy
+	}
+	added = make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		params := make([]interface{}, len(rule))
+		for i, v := range rule {
+			params[i] = v
+		}
+		exists, err := has(params...)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			added = append(added, rule)
+		} else if !skip {
+			return nil, nil
+		}
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+
+	// 先写入有效期再添加规则，其他实例收到通知时已经能读到有效期
+	if err = saveValidity(ctx, ptype, added, validity); err != nil {
+		return nil, err
+	}
+	var ok bool
+	if ptype == PTypeGrouping {
+		ok, err = e.AddGroupingPoliciesEx(added)
+	} else {
+		ok, err = e.AddPoliciesEx(added)
+	}
+	if err != nil || !ok {
+		dropValidity(ctx, ptype, added)
+		return nil, err
+	}
+	RecordAudit(ctx, &AuditEvent{Action: AuditAdd, PType: ptype, NewRules: added, Operation: operation})
+	return added, nil
+}
+
+// dropValidity 删除已经不存在的规则的有效期。规则变更已经生效，失败时只记录日志，残留的有效期会在过期清理或重新添加同一规则时处理。
+func dropValidity(ctx context.Context, ptype string, rules [][]string) {
+	if err := clearValidity(ctx, ptype, rules); err != nil {
+		g.Log().Warningf(ctx, "删除规则有效期失败: %v。规则：%v", err, rules)
+	}
+}
@@ -0,0 +1,263 @@
+package casbin
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// maxSimulateChecks 一次模拟最多重新判定的 (sub, dom, obj, act) 组数
+const maxSimulateChecks = 100000
+
+// ProposedChange 待模拟的规则修改
+type ProposedChange struct {
+	AddPolicies     [][]string
+	RemovePolicies  [][]string
+	AddGroupings    [][]string
+	RemoveGroupings [][]string
+}
+
+// AccessTuple 一次权限判定的请求
+type AccessTuple struct {
+	Sub string
+	Dom string
+	Obj string
+	Act string
+}
+
+// AccessChange 修改前后判定结果发生变化的请求
+type AccessChange struct {
+	AccessTuple
+	Before bool
+	After  bool
+}
+
+// SimulateResult 模拟结果
+type SimulateResult struct {
+	Checked   int            // 重新判定的请求组数
+	Changes   []AccessChange // 判定结果发生变化的请求
+	Policies  RuleDiff       // 实际生效的 p 规则变化，不含已经存在的新增规则和不存在的删除规则
+	Groupings RuleDiff       // 实际生效的 g 规则变化
+}
+
+// Simulate 在当前规则的内存副本上应用 change，重新判定 tuples，返回修改前后判定结果不同的请求。
+// 不写数据库，不通知 Watcher，也不修改当前生效的规则。
+//
+// 不传 tuples 时，由修改涉及的规则推断需要判定的请求：对象为修改前后受影响的 p 规则 sub、g 规则角色的所有成员，
+// 以及这些成员本身，只判定没有成员的对象（即用户）；资源和动作为修改前后这些角色适用的 p 规则的 obj 和 act，
+// 带通配符的 obj 按模式本身判定。角色继承只在同一个域内生效，所以只判定同一个域中的对象、资源和动作。
+// 属性条件规则适用的用户由用户信息决定，无法从规则推断，需要显式传入 tuples。
+// 推断出的请求超过 10 万组时返回错误，需要显式传入 tuples。判定前用 ctx 读取请求对象的用户信息。
+func Simulate(ctx context.Context, change *ProposedChange, tuples []AccessTuple) (*SimulateResult, error) {
+	for _, section := range []struct {
+		ptype string
+		rules [][]string
+	}{
+		{PTypePolicy, change.AddPolicies}, {PTypePolicy, change.RemovePolicies},
+		{PTypeGrouping, change.AddGroupings}, {PTypeGrouping, change.RemoveGroupings},
+	} {
+		for i, rule := range section.rules {
+			if problem := validateRule(section.ptype, rule); problem != "" {
+				return nil, gerror.Newf("第 %d 条 %v 规则不合法：%v", i+1, section.ptype, problem)
+			}
+		}
+	}
+
+	// 在同一个读锁下复制模型和规则，修改前后都基于这份副本判定
+	lock := e.GetLock()
+	lock.RLock()
+	m := e.Enforcer.GetModel().Copy()
+	policies, err := e.Enforcer.GetPolicy()
+	if err != nil {
+		lock.RUnlock()
+		return nil, gerror.Wrap(err, "读取当前 p 规则失败")
+	}
+	groupings, err := e.Enforcer.GetGroupingPolicy()
+	lock.RUnlock()
+	if err != nil {
+		return nil, gerror.Wrap(err, "读取当前 g 规则失败")
+	}
+
+	res := &SimulateResult{Changes: []AccessChange{}}
+	newPolicies, policyDiff := applyChange(policies, change.AddPolicies, change.RemovePolicies)
+	newGroupings, groupingDiff := applyChange(groupings, change.AddGroupings, change.RemoveGroupings)
+	res.Policies, res.Groupings = policyDiff, groupingDiff
+
+	before, err := newSimulator(m, policies, groupings)
+	if err != nil {
+		return nil, err
+	}
+	after, err := newSimulator(m, newPolicies, newGroupings)
+	if err != nil {
+		return nil, err
+	}
+	if len(tuples) == 0 {
+		if tuples, err = affectedTuples(before, after, policyDiff, groupingDiff); err != nil {
+			return nil, err
+		}
+	}
+	if len(tuples) == 0 {
+		return res, nil
+	}
+
+	requests := make([][]interface{}, len(tuples))
+	subs := make([]string, len(tuples))
+	for i, t := range tuples {
+		requests[i] = []interface{}{t.Sub, t.Dom, t.Obj, t.Act}
+		subs[i] = t.Sub
+	}
+	PrepareAttributes(ctx, subs...)
+	beforeAllows, err := before.BatchEnforce(requests)
+	if err != nil {
+		return nil, gerror.Wrap(err, "判定修改前的权限失败")
+	}
+	afterAllows, err := after.BatchEnforce(requests)
+	if err != nil {
+		return nil, gerror.Wrap(err, "判定修改后的权限失败")
+	}
+	res.Checked = len(tuples)
+	for i, t := range tuples {
+		if beforeAllows[i] != afterAllows[i] {
+			res.Changes = append(res.Changes, AccessChange{AccessTuple: t, Before: beforeAllows[i], After: afterAllows[i]})
+		}
+	}
+	return res, nil
+}
+
+// applyChange 返回对 rules 删除 remove、添加 add 之后的规则，以及与 rules 相比实际发生的变化
+func applyChange(rules, add, remove [][]string) ([][]string, RuleDiff) {
+	key := func(rule []string) string { return strings.Join(rule, "\x00") }
+	removeSet := make(map[string]struct{}, len(remove))
+	for _, rule := range remove {
+		removeSet[key(rule)] = struct{}{}
+	}
+	result := make([][]string, 0, len(rules)+len(add))
+	seen := make(map[string]struct{}, len(rules)+len(add))
+	for _, rule := range rules {
+		if _, ok := removeSet[key(rule)]; !ok {
+			result = append(result, rule)
+			seen[key(rule)] = struct{}{}
+		}
+	}
+	for _, rule := range add {
+		if _, ok := seen[key(rule)]; !ok {
+			result = append(result, rule)
+			seen[key(rule)] = struct{}{}
+		}
+	}
+	return result, diffRules(rules, result)
+}
+
+// newSimulator 用模型 m 和给定的规则创建一个不连接数据库的 Enforcer，鉴权函数和角色管理器与当前生效的 Enforcer 相同
+func newSimulator(m model.Model, policies, groupings [][]string) (*casbin.Enforcer, error) {
+	m = m.Copy()
+	m.ClearPolicy()
+	if err := m.AddPolicies("p", PTypePolicy, policies); err != nil {
+		return nil, gerror.Wrap(err, "构造模拟的 p 规则失败")
+	}
+	if err := m.AddPolicies("g", PTypeGrouping, groupings); err != nil {
+		return nil, gerror.Wrap(err, "构造模拟的 g 规则失败")
+	}
+	sim, err := casbin.NewEnforcer(m)
+	if err != nil {
+		return nil, gerror.Wrap(err, "创建模拟的 Enforcer 失败")
+	}
+	sim.AddFunction("ruleActive", ruleActiveFunc)
+	sim.AddFunction("attributeMatch", attributeMatchFunc)
+	sim.SetRoleManager(newValidityRoleManager(10))
+	if err = sim.BuildRoleLinks(); err != nil {
+		return nil, gerror.Wrap(err, "构造模拟的角色继承失败")
+	}
+	return sim, nil
+}
+
+// affectedTuples 推断修改可能影响的请求，见 Simulate
+func affectedTuples(before, after *casbin.Enforcer, policyDiff, groupingDiff RuleDiff) ([]AccessTuple, error) {
+	// 受影响的 sub：变化的 p 规则的 sub、变化的 g 规则的角色，各自在规则所在的域中
+	subs := map[node]struct{}{}
+	for _, rule := range slices.Concat(policyDiff.Added, policyDiff.Removed) {
+		subs[node{rule[0], rule[1]}] = struct{}{}
+	}
+	for _, rule := range slices.Concat(groupingDiff.Added, groupingDiff.Removed) {
+		subs[node{rule[1], rule[2]}] = struct{}{}
+	}
+
+	type objAct struct{ obj, act string }
+	users := map[string]map[string]struct{}{}   // 域 -> 用户
+	objActs := map[string]map[objAct]struct{}{} // 域 -> 资源和动作
+	for _, enforcer := range []*casbin.Enforcer{before, after} {
+		for sub := range subs {
+			if users[sub.dom] == nil {
+				users[sub.dom], objActs[sub.dom] = map[string]struct{}{}, map[objAct]struct{}{}
+			}
+			members, err := enforcer.GetImplicitUsersForRole(sub.name, sub.dom)
+			if err != nil {
+				return nil, gerror.Wrapf(err, "查询 %v 的成员失败", sub.name)
+			}
+			for _, name := range append(members, sub.name) {
+				direct, err := enforcer.GetUsersForRole(name, sub.dom)
+				if err != nil {
+					return nil, gerror.Wrapf(err, "查询 %v 的成员失败", name)
+				}
+				if len(direct) == 0 {
+					users[sub.dom][name] = struct{}{}
+				}
+			}
+			// 角色及其上级角色的规则都可能因为继承关系的变化而生效或失效
+			roles, err := enforcer.GetImplicitRolesForUser(sub.name, sub.dom)
+			if err != nil {
+				return nil, gerror.Wrapf(err, "查询 %v 继承的角色失败", sub.name)
+			}
+			for _, name := range append(roles, sub.name) {
+				rules, err := enforcer.GetFilteredPolicy(0, name, sub.dom)
+				if err != nil {
+					return nil, gerror.Wrapf(err, "查询 %v 的规则失败", name)
+				}
+				for _, rule := range rules {
+					objActs[sub.dom][objAct{rule[2], rule[3]}] = struct{}{}
+				}
+			}
+		}
+	}
+	total := 0
+	for dom := range users {
+		total += len(users[dom]) * len(objActs[dom])
+	}
+	if total > maxSimulateChecks {
+		return nil, gerror.Newf("修改影响 %d 组请求，超过 %d 组，请指定需要判定的请求", total, maxSimulateChecks)
+	}
+
+	domains := make([]string, 0, len(users))
+	for dom := range users {
+		domains = append(domains, dom)
+	}
+	slices.Sort(domains)
+	tuples := make([]AccessTuple, 0, total)
+	for _, dom := range domains {
+		sortedUsers := make([]string, 0, len(users[dom]))
+		for user := range users[dom] {
+			sortedUsers = append(sortedUsers, user)
+		}
+		slices.Sort(sortedUsers)
+		sortedObjActs := make([]objAct, 0, len(objActs[dom]))
+		for oa := range objActs[dom] {
+			sortedObjActs = append(sortedObjActs, oa)
+		}
+		slices.SortFunc(sortedObjActs, func(a, b objAct) int {
+			if c := strings.Compare(a.obj, b.obj); c != 0 {
+				return c
+			}
+			return strings.Compare(a.act, b.act)
+		})
+		for _, user := range sortedUsers {
+			for _, oa := range sortedObjActs {
+				tuples = append(tuples, AccessTuple{Sub: user, Dom: dom, Obj: oa.obj, Act: oa.act})
+			}
+		}
+	}
+	return tuples, nil
+}
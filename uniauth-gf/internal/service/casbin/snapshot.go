@@ -0,0 +1,269 @@
+package casbin
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// 快照类型
+const (
+	SnapshotManual   = "manual"   // 手动快照
+	SnapshotAuto     = "auto"     // 批量操作前的自动快照
+	SnapshotRollback = "rollback" // 回滚前对当前规则的自动快照，用于撤销回滚
+)
+
+// RuleDiff 两组规则之间的差异
+type RuleDiff struct {
+	Added   [][]string `json:"added" dc:"新增的规则"`
+	Removed [][]string `json:"removed" dc:"删除的规则"`
+}
+
+// SnapshotDiff 两个规则集合之间 p 规则和 g 规则的差异
+type SnapshotDiff struct {
+	Policies  RuleDiff `json:"policies" dc:"p 规则的差异"`
+	Groupings RuleDiff `json:"groupings" dc:"g 规则的差异"`
+}
+
+// 同一实例内整体替换规则（回滚、导入）串行执行
+var replaceMu sync.Mutex
+
+// TakeSnapshot 保存当前内存中的全部 p 规则和 g 规则，以及这些规则的有效期。
+//
+// 快照不参与调用方的事务，即使后续的批量操作失败回滚，快照也会保留。
+// 自动快照写入后会按 casbin.snapshotRetention 清理旧的自动快照。
+func TakeSnapshot(ctx context.Context, kind, name, operation string) (*entity.CasbinPolicySnapshot, error) {
+	policies, groupings, err := liveRules()
+	if err != nil {
+		return nil, err
+	}
+	snapshotCtx := gdb.WithoutTX(ctx, dao.CasbinPolicySnapshot.Group())
+	validities, err := loadRuleValidities(snapshotCtx, policies, groupings)
+	if err != nil {
+		return nil, err
+	}
+	actor, _, _ := auditContext(ctx)
+	snapshot := &entity.CasbinPolicySnapshot{
+		Name:          name,
+		Kind:          kind,
+		Operation:     operation,
+		Actor:         actor,
+		Policies:      gjson.New(policies),
+		Groupings:     gjson.New(groupings),
+		Validities:    gjson.New(validities),
+		PolicyCount:   len(policies),
+		GroupingCount: len(groupings),
+	}
+	snapshot.Id, err = dao.CasbinPolicySnapshot.Ctx(snapshotCtx).Data(g.Map{
+		"name":           snapshot.Name,
+		"kind":           snapshot.Kind,
+		"operation":      snapshot.Operation,
+		"actor":          snapshot.Actor,
+		"policies":       snapshot.Policies,
+		"groupings":      snapshot.Groupings,
+		"validities":     snapshot.Validities,
+		"policy_count":   snapshot.PolicyCount,
+		"grouping_count": snapshot.GroupingCount,
+	}).InsertAndGetId()
+	if err != nil {
+		return nil, gerror.Wrap(err, "保存 Casbin 规则快照失败")
+	}
+
+	if kind == SnapshotAuto {
+		if err := pruneAutoSnapshots(snapshotCtx); err != nil {
+			g.Log().Warningf(ctx, "清理旧的 Casbin 自动快照失败: %v", err)
+		}
+	}
+	return snapshot, nil
+}
+
+// GetSnapshot 查询一个快照，不存在时返回错误。
+func GetSnapshot(ctx context.Context, id int64) (snapshot *entity.CasbinPolicySnapshot, err error) {
+	if err = dao.CasbinPolicySnapshot.Ctx(ctx).Where("id", id).Scan(&snapshot); err != nil {
+		return nil, gerror.Wrapf(err, "查询 Casbin 规则快照 %v 失败", id)
+	}
+	if snapshot == nil {
+		return nil, gerror.Newf("Casbin 规则快照 %v 不存在", id)
+	}
+	return
+}
+
+// DiffSnapshots 对比两个快照，返回从 fromId 到 toId 的变化。toId 为 0 时与当前生效的规则对比。
+func DiffSnapshots(ctx context.Context, fromId, toId int64) (*SnapshotDiff, error) {
+	fromPolicies, fromGroupings, _, err := snapshotRules(ctx, fromId)
+	if err != nil {
+		return nil, err
+	}
+	var toPolicies, toGroupings [][]string
+	if toId == 0 {
+		toPolicies, toGroupings, err = liveRules()
+	} else {
+		toPolicies, toGroupings, _, err = snapshotRules(ctx, toId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotDiff{
+		Policies:  diffRules(fromPolicies, toPolicies),
+		Groupings: diffRules(fromGroupings, toGroupings),
+	}, nil
+}
+
+// RollbackToSnapshot 把全部 Casbin 规则和规则有效期恢复为快照中的状态。
+//
+// 回滚前先对当前规则做一次 rollback 类型的快照，回滚本身也可以撤销。
+// 规则在一个数据库事务中整体替换，失败时数据库保持原样；成功后重新加载本实例的规则，并通过 Watcher 通知其他实例重新加载。
+// 返回回滚带来的变化和回滚前的快照。
+func RollbackToSnapshot(ctx context.Context, id int64) (diff *SnapshotDiff, backup *entity.CasbinPolicySnapshot, err error) {
+	replaceMu.Lock()
+	defer replaceMu.Unlock()
+
+	policies, groupings, validities, err := snapshotRules(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	operation := "casbin.RollbackToSnapshot"
+	if backup, err = TakeSnapshot(ctx, SnapshotRollback, "", operation); err != nil {
+		return nil, nil, gerror.Wrap(err, "回滚前保存当前规则失败，没有回滚")
+	}
+	if diff, err = DiffSnapshots(ctx, backup.Id, id); err != nil {
+		return nil, nil, err
+	}
+
+	if err = replaceRules(ctx, operation, policies, groupings, validities, diff); err != nil {
+		return nil, nil, err
+	}
+	return diff, backup, nil
+}
+
+// replaceRules 把全部规则替换为 policies 和 groupings，规则有效期替换为 validities 中这些规则的有效期，没有有效期的规则永久有效。
+// 有效期在一个数据库事务中重写，规则最后写入，写入规则失败时有效期一起回滚，数据库保持原样。
+// 成功后记录 diff 的审计日志，重新加载本实例的有效期和规则，并通过 Watcher 通知其他实例。调用方需要持有 replaceMu。
+func replaceRules(ctx context.Context, operation string, policies, groupings [][]string, validities []RuleValidity, diff *SnapshotDiff) error {
+	m := e.GetModel().Copy()
+	m.ClearPolicy()
+	if err := m.AddPolicies("p", PTypePolicy, policies); err != nil {
+		return gerror.Wrap(err, "构造 p 规则失败，规则没有变化")
+	}
+	if err := m.AddPolicies("g", PTypeGrouping, groupings); err != nil {
+		return gerror.Wrap(err, "构造 g 规则失败，规则没有变化")
+	}
+	validities = filterValidities(validities, policies, groupings)
+	if err := dao.CasbinRuleValidity.Transaction(ctx, func(txCtx context.Context, tx gdb.TX) error {
+		if err := replaceValidities(txCtx, validities); err != nil {
+			return err
+		}
+		return e.GetAdapter().SavePolicy(m)
+	}); err != nil {
+		return gerror.Wrap(err, "写入规则失败，规则和有效期都没有变化")
+	}
+
+	recordDiffAudit(ctx, operation, PTypePolicy, diff.Policies)
+	recordDiffAudit(ctx, operation, PTypeGrouping, diff.Groupings)
+
+	if err := ReloadValidities(ctx); err != nil {
+		return gerror.Wrap(err, "规则已经写入，但本实例重新加载有效期失败")
+	}
+	if err := e.LoadPolicy(); err != nil {
+		return gerror.Wrap(err, "规则已经写入，但本实例重新加载规则失败")
+	}
+	if err := watcher.Update(); err != nil {
+		return gerror.Wrap(err, "规则已经写入，但通知其他实例重新加载规则失败")
+	}
+	return nil
+}
+
+func recordDiffAudit(ctx context.Context, operation, ptype string, diff RuleDiff) {
+	RecordAudit(ctx, &AuditEvent{Action: AuditRemove, PType: ptype, OldRules: diff.Removed, Operation: operation})
+	RecordAudit(ctx, &AuditEvent{Action: AuditAdd, PType: ptype, NewRules: diff.Added, Operation: operation})
+}
+
+// pruneAutoSnapshots 只保留最近 casbin.snapshotRetention 个自动快照，小于 0 时不清理。
+func pruneAutoSnapshots(ctx context.Context) error {
+	retention := g.Cfg().MustGetWithEnv(ctx, "casbin.snapshotRetention", 100).Int()
+	if retention < 0 {
+		return nil
+	}
+	// 第 retention+1 新的自动快照及更早的自动快照都会被删除
+	oldest, err := dao.CasbinPolicySnapshot.Ctx(ctx).
+		Fields("id").
+		Where("kind", SnapshotAuto).
+		OrderDesc("id").
+		Offset(retention).
+		Value()
+	if err != nil || oldest.IsEmpty() {
+		return err
+	}
+	_, err = dao.CasbinPolicySnapshot.Ctx(ctx).
+		Where("kind", SnapshotAuto).
+		WhereLTE("id", oldest.Int64()).
+		Delete()
+	return err
+}
+
+func liveRules() (policies, groupings [][]string, err error) {
+	if policies, err = e.GetPolicy(); err != nil {
+		return nil, nil, gerror.Wrap(err, "读取当前 p 规则失败")
+	}
+	if groupings, err = e.GetGroupingPolicy(); err != nil {
+		return nil, nil, gerror.Wrap(err, "读取当前 g 规则失败")
+	}
+	if policies == nil {
+		policies = [][]string{}
+	}
+	if groupings == nil {
+		groupings = [][]string{}
+	}
+	return
+}
+
+func snapshotRules(ctx context.Context, id int64) (policies, groupings [][]string, validities []RuleValidity, err error) {
+	snapshot, err := GetSnapshot(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err = snapshot.Policies.Scan(&policies); err != nil {
+		return nil, nil, nil, gerror.Wrapf(err, "解析快照 %v 中的 p 规则失败", id)
+	}
+	if err = snapshot.Groupings.Scan(&groupings); err != nil {
+		return nil, nil, nil, gerror.Wrapf(err, "解析快照 %v 中的 g 规则失败", id)
+	}
+	// 保存有效期之前的快照中没有有效期，恢复后规则永久有效
+	if snapshot.Validities != nil {
+		if err = snapshot.Validities.Scan(&validities); err != nil {
+			return nil, nil, nil, gerror.Wrapf(err, "解析快照 %v 中的规则有效期失败", id)
+		}
+	}
+	return
+}
+
+// diffRules 返回从 from 到 to 新增和删除的规则，保持各自原有的顺序。
+func diffRules(from, to [][]string) RuleDiff {
+	key := func(rule []string) string { return strings.Join(rule, "\x00") }
+	fromSet := make(map[string]struct{}, len(from))
+	for _, rule := range from {
+		fromSet[key(rule)] = struct{}{}
+	}
+	toSet := make(map[string]struct{}, len(to))
+	diff := RuleDiff{Added: [][]string{}, Removed: [][]string{}}
+	for _, rule := range to {
+		toSet[key(rule)] = struct{}{}
+		if _, ok := fromSet[key(rule)]; !ok {
+			diff.Added = append(diff.Added, rule)
+		}
+	}
+	for _, rule := range from {
+		if _, ok := toSet[key(rule)]; !ok {
+			diff.Removed = append(diff.Removed, rule)
+		}
+	}
+	return diff
+}
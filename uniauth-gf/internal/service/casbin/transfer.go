@@ -0,0 +1,342 @@
+package casbin
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/model/entity"
+)
+
+// 导入导出的文件格式。设置了有效期的规则在末尾多两列生效时间和失效时间（RFC 3339），为空表示不限
+const (
+	FormatCsv  = "csv"  // Casbin 的 CSV 策略文件，每行一条规则，第一列为 p 或 g
+	FormatJson = "json" // {"policies": [[sub, dom, obj, act, eft], ...], "groupings": [[member, role, dom], ...]}
+)
+
+// 导入模式
+const (
+	ImportMerge   = "merge"   // 只添加文件中有而当前没有的规则
+	ImportReplace = "replace" // 把全部规则替换为文件中的规则
+)
+
+// maxImportErrors 校验导入文件时最多报告的错误条数
+const maxImportErrors = 20
+
+// ExportFilter 导出规则的筛选条件，为空的字段不筛选
+type ExportFilter struct {
+	PType string // p 或 g，为空时两者都导出
+	Dom   string // 规则所在的域
+	Sub   string // p 规则的 sub 或 g 规则的成员
+	Obj   string // p 规则的 obj
+	Role  string // g 规则的角色
+}
+
+// rulesJson JSON 格式的规则文件
+type rulesJson struct {
+	Policies  [][]string `json:"policies"`
+	Groupings [][]string `json:"groupings"`
+}
+
+// ExportRules 按筛选条件取出当前生效的规则，设置了有效期的规则末尾追加生效时间和失效时间两列
+func ExportRules(filter ExportFilter) (policies, groupings [][]string, err error) {
+	all, allGroupings, err := liveRules()
+	if err != nil {
+		return nil, nil, err
+	}
+	policies, groupings = [][]string{}, [][]string{}
+	if filter.PType != PTypeGrouping && filter.Role == "" {
+		for _, rule := range all {
+			if (filter.Dom == "" || RuleDomain(PTypePolicy, rule) == filter.Dom) &&
+				(filter.Sub == "" || rule[0] == filter.Sub) && (filter.Obj == "" || rule[2] == filter.Obj) {
+				policies = append(policies, withValidityColumns(PTypePolicy, rule))
+			}
+		}
+	}
+	if filter.PType != PTypePolicy && filter.Obj == "" {
+		for _, rule := range allGroupings {
+			if (filter.Dom == "" || RuleDomain(PTypeGrouping, rule) == filter.Dom) &&
+				(filter.Sub == "" || rule[0] == filter.Sub) && (filter.Role == "" || rule[1] == filter.Role) {
+				groupings = append(groupings, withValidityColumns(PTypeGrouping, rule))
+			}
+		}
+	}
+	return
+}
+
+// withValidityColumns 规则设置了有效期时，返回末尾追加生效时间和失效时间两列的规则
+func withValidityColumns(ptype string, rule []string) []string {
+	v := ruleValidity(ptype, rule)
+	if v.IsZero() {
+		return rule
+	}
+	column := func(t *gtime.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Time.Format(time.RFC3339)
+	}
+	return append(append([]string{}, rule...), column(v.From), column(v.Until))
+}
+
+// splitValidityColumns 从文件中的一行拆出规则和末尾的有效期两列，没有有效期列时原样返回。字段个数不对时交给 validateRule 报错
+func splitValidityColumns(ptype string, fields []string) (rule []string, validity Validity, problem string) {
+	n := 0
+	switch ptype {
+	case PTypePolicy:
+		n = 5
+	case PTypeGrouping:
+		n = 3
+	}
+	if n == 0 || len(fields) != n+2 {
+		return fields, Validity{}, ""
+	}
+	times := make([]*gtime.Time, 2)
+	for i, field := range fields[n:] {
+		if field == "" {
+			continue
+		}
+		t, err := gtime.StrToTime(field)
+		if err != nil {
+			return nil, Validity{}, fmt.Sprintf("第 %d 个字段不是合法的时间：%v", n+i+1, field)
+		}
+		times[i] = t
+	}
+	validity = Validity{From: times[0], Until: times[1]}
+	if validity.From != nil && validity.Until != nil && !validity.From.Before(validity.Until) {
+		return nil, Validity{}, "生效时间必须早于失效时间"
+	}
+	return fields[:n], validity, ""
+}
+
+// csvHeader CSV 文件开头的注释行，说明各列的含义。没有规则时文件也不为空，导入时按注释跳过
+const csvHeader = "# p, sub, dom, obj, act, eft[, validFrom, validUntil]\n# g, member, role, dom[, validFrom, validUntil]\n"
+
+// WriteRules 把规则按 format 写入 w
+func WriteRules(w io.Writer, format string, policies, groupings [][]string) error {
+	switch format {
+	case FormatCsv:
+		if _, err := io.WriteString(w, csvHeader); err != nil {
+			return gerror.Wrap(err, "写入 CSV 失败")
+		}
+		writer := csv.NewWriter(w)
+		for _, rules := range []struct {
+			ptype string
+			rules [][]string
+		}{{PTypePolicy, policies}, {PTypeGrouping, groupings}} {
+			for _, rule := range rules.rules {
+				if err := writer.Write(append([]string{rules.ptype}, rule...)); err != nil {
+					return gerror.Wrap(err, "写入 CSV 失败")
+				}
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case FormatJson:
+		// 每条规则占一行，便于阅读和对比
+		buf := bufio.NewWriter(w)
+		for i, section := range []struct {
+			name  string
+			rules [][]string
+		}{{"policies", policies}, {"groupings", groupings}} {
+			if i == 0 {
+				buf.WriteString("{\n")
+			} else {
+				buf.WriteString(",\n")
+			}
+			fmt.Fprintf(buf, "  %q: [", section.name)
+			for j, rule := range section.rules {
+				line, err := json.Marshal(rule)
+				if err != nil {
+					return gerror.Wrap(err, "写入 JSON 失败")
+				}
+				if j > 0 {
+					buf.WriteString(",")
+				}
+				buf.WriteString("\n    ")
+				buf.Write(line)
+			}
+			if len(section.rules) > 0 {
+				buf.WriteString("\n  ")
+			}
+			buf.WriteString("]")
+		}
+		buf.WriteString("\n}\n")
+		return buf.Flush()
+	}
+	return gerror.Newf("不支持的格式：%v", format)
+}
+
+// ParseRules 解析并校验规则文件。p 规则必须是 sub, dom, obj, act, eft 五列且 eft 为 allow 或 deny，g 规则必须是成员, 角色, dom 三列，
+// 不能有空字段，属性条件必须能够编译；末尾可以再有生效时间和失效时间两列。
+// 文件中重复的规则只保留第一条。有错误时返回所有（最多 20 条）错误的位置和原因。
+func ParseRules(r io.Reader, format string) (policies, groupings [][]string, validities []RuleValidity, err error) {
+	type line struct {
+		pos   string
+		ptype string
+		rule  []string
+	}
+	var lines []line
+	switch format {
+	case FormatCsv:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		reader.Comment = '#'
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, nil, nil, gerror.Wrap(err, "解析 CSV 失败")
+			}
+			row, _ := reader.FieldPos(0)
+			for i := range record {
+				record[i] = strings.TrimSpace(record[i])
+			}
+			lines = append(lines, line{pos: fmt.Sprintf("第 %d 行", row), ptype: record[0], rule: record[1:]})
+		}
+	case FormatJson:
+		var file rulesJson
+		if err := json.NewDecoder(r).Decode(&file); err != nil {
+			return nil, nil, nil, gerror.Wrap(err, "解析 JSON 失败")
+		}
+		for i, rule := range file.Policies {
+			lines = append(lines, line{pos: fmt.Sprintf("policies[%d]", i), ptype: PTypePolicy, rule: rule})
+		}
+		for i, rule := range file.Groupings {
+			lines = append(lines, line{pos: fmt.Sprintf("groupings[%d]", i), ptype: PTypeGrouping, rule: rule})
+		}
+	default:
+		return nil, nil, nil, gerror.Newf("不支持的格式：%v", format)
+	}
+
+	var problems []string
+	seen := make(map[string]struct{}, len(lines))
+	policies, groupings = [][]string{}, [][]string{}
+	validities = []RuleValidity{}
+	for _, l := range lines {
+		rule, validity, problem := splitValidityColumns(l.ptype, l.rule)
+		if problem == "" {
+			problem = validateRule(l.ptype, rule)
+		}
+		if problem != "" {
+			if len(problems) < maxImportErrors {
+				problems = append(problems, l.pos+"："+problem)
+			}
+			continue
+		}
+		key := ruleKey(l.ptype, rule)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if l.ptype == PTypePolicy {
+			policies = append(policies, rule)
+		} else {
+			groupings = append(groupings, rule)
+		}
+		if !validity.IsZero() {
+			validities = append(validities, RuleValidity{PType: l.ptype, Rule: rule, ValidFrom: validity.From, ValidUntil: validity.Until})
+		}
+	}
+	if len(problems) > 0 {
+		return nil, nil, nil, gerror.Newf("规则文件校验失败：\n%v", strings.Join(problems, "\n"))
+	}
+	return policies, groupings, validities, nil
+}
+
+func validateRule(ptype string, rule []string) string {
+	for i, field := range rule {
+		if field == "" {
+			return fmt.Sprintf("第 %d 个字段为空", i+1)
+		}
+	}
+	switch ptype {
+	case PTypePolicy:
+		if len(rule) != 5 {
+			return fmt.Sprintf("p 规则需要 sub, dom, obj, act, eft 五个字段，实际有 %d 个", len(rule))
+		}
+		if rule[4] != "allow" && rule[4] != "deny" {
+			return fmt.Sprintf("eft 只能是 allow 或 deny，实际为 %v", rule[4])
+		}
+		if IsAttributeSubject(rule[0]) {
+			if err := CheckCondition(rule[0]); err != nil {
+				return err.Error()
+			}
+		}
+	case PTypeGrouping:
+		if len(rule) != 3 {
+			return fmt.Sprintf("g 规则需要成员、角色和 dom 三个字段，实际有 %d 个", len(rule))
+		}
+		if IsAttributeSubject(rule[0]) || IsAttributeSubject(rule[1]) {
+			return "属性条件只能作为 p 规则的 sub，不能出现在 g 规则中"
+		}
+	default:
+		return fmt.Sprintf("规则类型只能是 p 或 g，实际为 %v", ptype)
+	}
+	if err := CheckDomain(RuleDomain(ptype, rule)); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// ImportRules 导入规则。merge 模式只添加当前没有的规则，新规则使用文件中的有效期，已有规则的有效期不变；
+// replace 模式把全部规则和有效期替换为文件中的规则和有效期，文件中没有的规则会被删除，文件中没有有效期的规则永久有效。
+//
+// dryRun 为 true 时只返回与当前生效规则的差异，不做任何修改。
+// 否则先对当前规则做一次自动快照，再在一个数据库事务中整体写入，失败时规则保持不变，可以用快照撤销本次导入。
+func ImportRules(ctx context.Context, policies, groupings [][]string, validities []RuleValidity, mode string, dryRun bool) (diff *SnapshotDiff, backup *entity.CasbinPolicySnapshot, err error) {
+	if mode != ImportMerge && mode != ImportReplace {
+		return nil, nil, gerror.Newf("不支持的导入模式：%v", mode)
+	}
+	if !dryRun {
+		replaceMu.Lock()
+		defer replaceMu.Unlock()
+	}
+
+	livePolicies, liveGroupings, err := liveRules()
+	if err != nil {
+		return nil, nil, err
+	}
+	liveValidities, err := loadRuleValidities(ctx, livePolicies, liveGroupings)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mode == ImportMerge {
+		policies = append(append([][]string{}, livePolicies...), diffRules(livePolicies, policies).Added...)
+		groupings = append(append([][]string{}, liveGroupings...), diffRules(liveGroupings, groupings).Added...)
+		// filterValidities 对同一条规则只保留第一个有效期，已有规则沿用当前的有效期
+		validities = append(append([]RuleValidity{}, liveValidities...), validities...)
+	}
+	validities = filterValidities(validities, policies, groupings)
+	diff = &SnapshotDiff{
+		Policies:  diffRules(livePolicies, policies),
+		Groupings: diffRules(liveGroupings, groupings),
+	}
+	if dryRun {
+		return diff, nil, nil
+	}
+	if len(diff.Policies.Added)+len(diff.Policies.Removed)+len(diff.Groupings.Added)+len(diff.Groupings.Removed) == 0 &&
+		sameValidities(liveValidities, validities) {
+		return diff, nil, nil
+	}
+
+	operation := "casbin.ImportRules"
+	if backup, err = TakeSnapshot(ctx, SnapshotAuto, "导入规则前", operation); err != nil {
+		return nil, nil, gerror.Wrap(err, "导入前保存当前规则失败，没有导入")
+	}
+	if err = replaceRules(ctx, operation, policies, groupings, validities, diff); err != nil {
+		return nil, nil, err
+	}
+	return diff, backup, nil
+}
@@ -0,0 +1,452 @@
+package casbin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/rbac"
+	defaultrolemanager "github.com/casbin/casbin/v2/rbac/default-role-manager"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// 过期规则清理使用的 Postgres advisory lock 键，多个实例同时执行时只有一个实例真正清理
+const purgeLockKey int64 = 0x6361_7362_696e_7075
+
+// Validity 规则的有效期，From 为空表示立即生效，Until 为空表示永不过期
+type Validity struct {
+	From  *gtime.Time
+	Until *gtime.Time
+}
+
+// IsZero 没有设置有效期
+func (v Validity) IsZero() bool {
+	return v.From == nil && v.Until == nil
+}
+
+// RuleValidity 一条规则及其有效期，快照和导入导出时随规则一起保存和恢复
+type RuleValidity struct {
+	PType      string      `json:"ptype"`
+	Rule       []string    `json:"rule"`
+	ValidFrom  *gtime.Time `json:"validFrom,omitempty"`
+	ValidUntil *gtime.Time `json:"validUntil,omitempty"`
+}
+
+// activeAt 判断 t 是否在有效期内
+func (v Validity) activeAt(t time.Time) bool {
+	if v.From != nil && t.Before(v.From.Time) {
+		return false
+	}
+	if v.Until != nil && !t.Before(v.Until.Time) {
+		return false
+	}
+	return true
+}
+
+// validities 内存中的规则有效期，键为 ruleKey。没有记录的规则永久有效。
+var (
+	validityMu       sync.RWMutex
+	validities       = map[string]Validity{}
+	hasGroupValidity bool // 存在设置了有效期的 g 规则时，角色管理器才需要逐条检查继承关系
+)
+
+func ruleKey(ptype string, rule []string) string {
+	return ptype + "\x1f" + strings.Join(rule, "\x1f")
+}
+
+// RuleActive 判断规则当前是否在有效期内，没有设置有效期的规则始终有效
+func RuleActive(ptype string, rule []string) bool {
+	validityMu.RLock()
+	v, ok := validities[ruleKey(ptype, rule)]
+	validityMu.RUnlock()
+	return !ok || v.activeAt(time.Now())
+}
+
+// ruleActiveFunc 注册到 Casbin matcher 中的 ruleActive(p.sub, p.dom, p.obj, p.act, p.eft)，有效期外的 p 规则不参与鉴权
+func ruleActiveFunc(args ...interface{}) (interface{}, error) {
+	rule := make([]string, len(args))
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, gerror.Newf("ruleActive 的参数必须是字符串，第 %d 个参数为 %T", i+1, arg)
+		}
+		rule[i] = s
+	}
+	return RuleActive(PTypePolicy, rule), nil
+}
+
+// ReloadValidities 从数据库重新加载全部规则有效期。
+//
+// 修改有效期时先写数据库再修改 Casbin 规则，其他实例收到 Watcher 通知后先重新加载有效期，所以不会短暂地看到没有有效期的新规则。
+func ReloadValidities(ctx context.Context) error {
+	var rows []*entity.CasbinRuleValidity
+	if err := dao.CasbinRuleValidity.Ctx(ctx).Scan(&rows); err != nil {
+		return gerror.Wrap(err, "加载 Casbin 规则有效期失败")
+	}
+	loaded := make(map[string]Validity, len(rows))
+	hasGroup := false
+	for _, row := range rows {
+		var rule []string
+		if err := row.Rule.Scan(&rule); err != nil {
+			g.Log().Warningf(ctx, "跳过无法解析的 Casbin 规则有效期 %v: %v", row.Id, err)
+			continue
+		}
+		loaded[ruleKey(row.Ptype, rule)] = Validity{From: row.ValidFrom, Until: row.ValidUntil}
+		hasGroup = hasGroup || row.Ptype == PTypeGrouping
+	}
+	validityMu.Lock()
+	validities, hasGroupValidity = loaded, hasGroup
+	validityMu.Unlock()
+	return nil
+}
+
+// loadRuleValidities 从数据库读取全部规则有效期，只保留 policies 和 groupings 中存在的规则
+func loadRuleValidities(ctx context.Context, policies, groupings [][]string) ([]RuleValidity, error) {
+	var rows []*entity.CasbinRuleValidity
+	if err := dao.CasbinRuleValidity.Ctx(ctx).OrderAsc("id").Scan(&rows); err != nil {
+		return nil, gerror.Wrap(err, "读取 Casbin 规则有效期失败")
+	}
+	res := make([]RuleValidity, 0, len(rows))
+	for _, row := range rows {
+		var rule []string
+		if err := row.Rule.Scan(&rule); err != nil {
+			g.Log().Warningf(ctx, "跳过无法解析的 Casbin 规则有效期 %v: %v", row.Id, err)
+			continue
+		}
+		res = append(res, RuleValidity{PType: row.Ptype, Rule: rule, ValidFrom: row.ValidFrom, ValidUntil: row.ValidUntil})
+	}
+	return filterValidities(res, policies, groupings), nil
+}
+
+// filterValidities 只保留 policies 和 groupings 中存在的规则的有效期，同一条规则只保留第一个有效期
+func filterValidities(validities []RuleValidity, policies, groupings [][]string) []RuleValidity {
+	exists := make(map[string]bool, len(policies)+len(groupings))
+	for _, rule := range policies {
+		exists[ruleKey(PTypePolicy, rule)] = true
+	}
+	for _, rule := range groupings {
+		exists[ruleKey(PTypeGrouping, rule)] = true
+	}
+	res := make([]RuleValidity, 0, len(validities))
+	for _, v := range validities {
+		key := ruleKey(v.PType, v.Rule)
+		if exists[key] && (v.ValidFrom != nil || v.ValidUntil != nil) {
+			res = append(res, v)
+			exists[key] = false
+		}
+	}
+	return res
+}
+
+// sameValidities a 和 b 中的规则有效期是否完全相同，不考虑顺序
+func sameValidities(a, b []RuleValidity) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	instant := func(t *gtime.Time) string {
+		if t == nil {
+			return ""
+		}
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+	key := func(v RuleValidity) string {
+		return ruleKey(v.PType, v.Rule) + "\x00" + instant(v.ValidFrom) + "\x00" + instant(v.ValidUntil)
+	}
+	counts := make(map[string]int, len(a))
+	for _, v := range a {
+		counts[key(v)]++
+	}
+	for _, v := range b {
+		if counts[key(v)] == 0 {
+			return false
+		}
+		counts[key(v)]--
+	}
+	return true
+}
+
+// replaceValidities 把全部规则有效期替换为 validities，需要在调用方的事务中执行
+func replaceValidities(ctx context.Context, validities []RuleValidity) error {
+	if _, err := dao.CasbinRuleValidity.Ctx(ctx).WhereGT("id", 0).Delete(); err != nil {
+		return gerror.Wrap(err, "清空 Casbin 规则有效期失败")
+	}
+	if len(validities) == 0 {
+		return nil
+	}
+	data := make(g.List, 0, len(validities))
+	for _, v := range validities {
+		data = append(data, g.Map{
+			"ptype":       v.PType,
+			"rule":        gjson.New(v.Rule),
+			"valid_from":  v.ValidFrom,
+			"valid_until": v.ValidUntil,
+		})
+	}
+	if _, err := dao.CasbinRuleValidity.Ctx(ctx).Data(data).Insert(); err != nil {
+		return gerror.Wrap(err, "写入 Casbin 规则有效期失败")
+	}
+	return nil
+}
+
+// ruleValidity 返回规则在内存中的有效期，没有设置有效期时返回零值
+func ruleValidity(ptype string, rule []string) Validity {
+	validityMu.RLock()
+	defer validityMu.RUnlock()
+	return validities[ruleKey(ptype, rule)]
+}
+
+// saveValidity 为规则写入有效期，有效期为空时删除已有的有效期，避免重新添加的规则沿用旧的有效期。
+// 有效期不参与调用方的事务，和 Casbin 规则一样立即生效。
+func saveValidity(ctx context.Context, ptype string, rules [][]string, validity Validity) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	ctx = gdb.WithoutTX(ctx, dao.CasbinRuleValidity.Group())
+	if validity.IsZero() {
+		return clearValidity(ctx, ptype, rules)
+	}
+	data := make(g.List, 0, len(rules))
+	for _, rule := range rules {
+		data = append(data, g.Map{
+			"ptype":       ptype,
+			"rule":        gjson.New(rule),
+			"valid_from":  validity.From,
+			"valid_until": validity.Until,
+		})
+	}
+	if _, err := dao.CasbinRuleValidity.Ctx(ctx).
+		Data(data).
+		OnConflict("ptype", "rule").
+		OnDuplicate("valid_from", "valid_until").
+		Save(); err != nil {
+		return gerror.Wrap(err, "写入 Casbin 规则有效期失败")
+	}
+	return ReloadValidities(ctx)
+}
+
+// clearValidity 删除规则的有效期，在规则被删除或修改后调用。
+func clearValidity(ctx context.Context, ptype string, rules [][]string) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	ctx = gdb.WithoutTX(ctx, dao.CasbinRuleValidity.Group())
+	validityMu.RLock()
+	found := false
+	for _, rule := range rules {
+		if _, ok := validities[ruleKey(ptype, rule)]; ok {
+			found = true
+			break
+		}
+	}
+	validityMu.RUnlock()
+	if !found {
+		return nil
+	}
+	for _, rule := range rules {
+		if _, err := dao.CasbinRuleValidity.Ctx(ctx).
+			Where("ptype", ptype).
+			Where("rule = ?::jsonb", gjson.New(rule).MustToJsonString()).
+			Delete(); err != nil {
+			return gerror.Wrap(err, "删除 Casbin 规则有效期失败")
+		}
+	}
+	return ReloadValidities(ctx)
+}
+
+// PurgeExpiredRules 删除所有已经过期的规则，返回删除的条数。
+//
+// 删除通过审计过的变更函数完成，会写入审计日志并通过 Watcher 通知其他实例。
+// 清理期间持有事务级 advisory lock，其他实例拿不到锁时直接跳过本轮。
+// 某条规则删除失败时记录日志并继续清理其他规则，下一轮再重试这条规则。
+func PurgeExpiredRules(ctx context.Context) (purged int, err error) {
+	err = dao.CasbinRuleValidity.Transaction(ctx, func(txCtx context.Context, tx gdb.TX) error {
+		locked, err := tx.GetValue("SELECT pg_try_advisory_xact_lock(?)", purgeLockKey)
+		if err != nil {
+			return gerror.Wrap(err, "获取过期规则清理锁失败")
+		}
+		if !locked.Bool() {
+			return nil
+		}
+
+		var rows []*entity.CasbinRuleValidity
+		// 查询和删除不在持锁事务中进行
+		if err = dao.CasbinRuleValidity.Ctx(ctx).WhereLTE("valid_until", gtime.Now()).Scan(&rows); err != nil {
+			return gerror.Wrap(err, "查询过期规则失败")
+		}
+		for _, row := range rows {
+			var rule []string
+			if err = row.Rule.Scan(&rule); err != nil {
+				g.Log().Warningf(ctx, "跳过无法解析的过期规则 %v: %v", row.Id, err)
+				continue
+			}
+			var ok bool
+			if row.Ptype == PTypeGrouping {
+				ok, err = RemoveGroupingPolicies(ctx, "casbin.PurgeExpiredRules", [][]string{rule})
+			} else {
+				ok, err = RemovePolicies(ctx, "casbin.PurgeExpiredRules", [][]string{rule})
+			}
+			if err != nil {
+				g.Log().Warningf(ctx, "删除过期规则 %v 失败，下一轮重试: %v", rule, err)
+				continue
+			}
+			if ok {
+				purged++
+			} else if err = clearValidity(ctx, row.Ptype, [][]string{rule}); err != nil {
+				// 规则已经不存在，只需要删除有效期
+				g.Log().Warningf(ctx, "删除已不存在的规则 %v 的有效期失败，下一轮重试: %v", rule, err)
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// activationCheckedAt 上次检查 g 规则生效时间的时间点
+var (
+	activationMu        sync.Mutex
+	activationCheckedAt = time.Now()
+)
+
+// RefreshActivatedRules 有 g 规则的生效时间在上次检查之后到达时，重新加载 Casbin 规则，返回是否重新加载。
+//
+// Casbin 会缓存 g() 的判断结果，直到规则重新加载才会失效，生效时间未到时缓存的拒绝结果会一直保留。
+// 由每分钟的定时任务调用，所以生效时间到达后最多约 1 分钟继承关系才会生效。
+// 缓存在每个实例的内存中，每个实例都需要执行，不使用 advisory lock。
+func RefreshActivatedRules(ctx context.Context) (bool, error) {
+	activationMu.Lock()
+	defer activationMu.Unlock()
+	now := time.Now()
+	activated := false
+	validityMu.RLock()
+	for key, v := range validities {
+		if v.From != nil && strings.HasPrefix(key, PTypeGrouping+"\x1f") &&
+			v.From.Time.After(activationCheckedAt) && !v.From.Time.After(now) {
+			activated = true
+			break
+		}
+	}
+	validityMu.RUnlock()
+	activationCheckedAt = now
+	if !activated {
+		return false, nil
+	}
+	if err := e.LoadPolicy(); err != nil {
+		return false, gerror.Wrap(err, "重新加载 Casbin 规则失败")
+	}
+	return true, nil
+}
+
+// validityRoleManager 在默认的带域角色管理器的基础上忽略有效期外的 g 规则。
+// 没有任何 g 规则设置有效期时，直接使用默认实现。
+type validityRoleManager struct {
+	*defaultrolemanager.DomainManager
+	maxHierarchyLevel int
+}
+
+func newValidityRoleManager(maxHierarchyLevel int) rbac.RoleManager {
+	return &validityRoleManager{
+		DomainManager:     defaultrolemanager.NewDomainManager(maxHierarchyLevel),
+		maxHierarchyLevel: maxHierarchyLevel,
+	}
+}
+
+func (rm *validityRoleManager) checkLinks() bool {
+	validityMu.RLock()
+	defer validityMu.RUnlock()
+	return hasGroupValidity
+}
+
+func (rm *validityRoleManager) GetRoles(name string, domain ...string) ([]string, error) {
+	roles, err := rm.DomainManager.GetRoles(name, domain...)
+	if err != nil || !rm.checkLinks() {
+		return roles, err
+	}
+	active := roles[:0:0]
+	for _, role := range roles {
+		if RuleActive(PTypeGrouping, append([]string{name, role}, domain...)) {
+			active = append(active, role)
+		}
+	}
+	return active, nil
+}
+
+func (rm *validityRoleManager) GetUsers(name string, domain ...string) ([]string, error) {
+	users, err := rm.DomainManager.GetUsers(name, domain...)
+	if err != nil || !rm.checkLinks() {
+		return users, err
+	}
+	active := users[:0:0]
+	for _, user := range users {
+		if RuleActive(PTypeGrouping, append([]string{user, name}, domain...)) {
+			active = append(active, user)
+		}
+	}
+	return active, nil
+}
+
+func (rm *validityRoleManager) HasLink(name1 string, name2 string, domain ...string) (bool, error) {
+	if !rm.checkLinks() {
+		return rm.DomainManager.HasLink(name1, name2, domain...)
+	}
+	if name1 == name2 {
+		return true, nil
+	}
+	roles, err := rm.expand(name1, rm.GetRoles, domain...)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if role == name2 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (rm *validityRoleManager) GetImplicitRoles(name string, domain ...string) ([]string, error) {
+	if !rm.checkLinks() {
+		return rm.DomainManager.GetImplicitRoles(name, domain...)
+	}
+	return rm.expand(name, rm.GetRoles, domain...)
+}
+
+func (rm *validityRoleManager) GetImplicitUsers(name string, domain ...string) ([]string, error) {
+	if !rm.checkLinks() {
+		return rm.DomainManager.GetImplicitUsers(name, domain...)
+	}
+	return rm.expand(name, rm.GetUsers, domain...)
+}
+
+// expand 按层展开 name 直接或间接关联的所有名称，不包含 name 本身
+func (rm *validityRoleManager) expand(name string, next func(string, ...string) ([]string, error), domain ...string) ([]string, error) {
+	var res []string
+	seen := map[string]bool{name: true}
+	frontier := []string{name}
+	for level := 0; level < rm.maxHierarchyLevel && len(frontier) > 0; level++ {
+		var nextFrontier []string
+		for _, n := range frontier {
+			names, err := next(n, domain...)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range names {
+				if !seen[m] {
+					seen[m] = true
+					res = append(res, m)
+					nextFrontier = append(nextFrontier, m)
+				}
+			}
+		}
+		frontier = nextFrontier
+	}
+	return res, nil
+}
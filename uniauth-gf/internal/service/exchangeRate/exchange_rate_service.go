@@ -2,7 +2,7 @@ package exchangeRate
 
 import (
 	"context"
-	"encoding/json"
+	"sync"
 	"time"
 	"uniauth-gf/internal/dao"
 
@@ -14,65 +14,77 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// 交叉换算使用的中间货币
+const pivotCurrency = "USD"
+
+// 同一时刻只向汇率来源请求一次
+var fetchGroup singleflight.Group
+
+// 汇率来源请求失败后的冷却时间，冷却期内不再请求，直接使用数据库中最近一次的汇率
+const fetchFailureCooldown = time.Minute
+
+var (
+	fetchFailureMu sync.Mutex
+	fetchFailedAt  time.Time
+)
+
+// GetExchangeRate 获取今天 1 f = ? t 的汇率。
 func GetExchangeRate(ctx context.Context, f string, t string) (decimal.Decimal, error) {
-	rateRaw, err := dao.ConfigExchangeRate.Ctx(ctx).
-		Fields("rate").
-		Where("f = ?", f).
-		Where("t = ?", t).
-		Where("date = ?", gtime.Date()).
-		Value(); 
-	if err != nil {
-		return decimal.Zero, err
+	rate, _, err := GetRateAt(ctx, f, t, gtime.Now())
+	return rate, err
+}
+
+// fetchTodayRates 从汇率来源获取最新汇率并写入为今天的汇率，fetched 表示是否真正请求并写入了汇率。
+//
+// 只使用手动上传的汇率时不请求；上一次请求失败后 fetchFailureCooldown 内也不再请求，避免汇率来源不可用时每次换算都等待超时。
+func fetchTodayRates(ctx context.Context) (fetched bool, err error) {
+	p := GetProvider(ctx)
+	if _, ok := p.(ManualProvider); ok {
+		return false, nil
 	}
-	if !rateRaw.IsNil() {
-		rate, err := decimal.NewFromString(rateRaw.String())
-		if err != nil {
-			return decimal.Zero, err
-		}
-		return rate, nil
+	fetchFailureMu.Lock()
+	cooling := time.Since(fetchFailedAt) < fetchFailureCooldown
+	fetchFailureMu.Unlock()
+	if cooling {
+		return false, nil
 	}
-
-	// 走到这里说明数据库里面没有汇率数据，需要请求API
-	// 使用 singleflight 解决并发竞争问题
-	g := new(singleflight.Group)
-	v, err, _ := g.Do("getRateApi", func() (any, error) {
-		rate, err := getRateApi(ctx)
+	_, err, _ = fetchGroup.Do("fetchTodayRates", func() (any, error) {
+		err := storeTodayRates(ctx, p)
 		if err != nil {
-			return decimal.Zero, err
+			fetchFailureMu.Lock()
+			fetchFailedAt = time.Now()
+			fetchFailureMu.Unlock()
 		}
-		return rate, nil
+		return nil, err
 	})
-	if err != nil {
-		return decimal.Zero, err
-	}
-	return v.(decimal.Decimal), nil
+	return err == nil, err
 }
 
-func getRateApi(ctx context.Context) (decimal.Decimal, error) {
-	r := g.Client().Timeout(time.Duration(3*1000*1000*1000)).GetBytes(ctx, "https://v6.exchangerate-api.com/v6/badaf9f96a065fc7b17b662d/latest/USD")
-	var rJson g.Map
-	if err := json.Unmarshal(r, &rJson); err != nil {
-		return decimal.Zero, gerror.Wrapf(err, "汇率接口返回信息反序列化失败。[DEBUG]原始响应：%v", r)
+func storeTodayRates(ctx context.Context, p ExchangeRateProvider) error {
+	rateSet, err := p.LatestRates(ctx)
+	if err != nil {
+		return err
 	}
-	result, ok := rJson["result"].(string)
-	if !ok || result != "success" {
-		return decimal.Zero, gerror.New("汇率 API 接口返回的 result 不是 success，API 接口异常。")
+
+	// 数据库写入，已有的汇率（例如管理员手动上传的）不覆盖
+	today := gtime.Date()
+	data := make(g.List, 0, len(rateSet.Rates))
+	for currency, rate := range rateSet.Rates {
+		if currency == rateSet.Base || !rate.IsPositive() {
+			continue
+		}
+		data = append(data, g.Map{
+			"date": today,
+			"f":    rateSet.Base,
+			"t":    currency,
+			"rate": rate,
+		})
 	}
-	rate, ok := rJson["conversion_rates"].(g.Map)["CNY"].(float64)
-	if !ok {
-		return decimal.Zero, gerror.New("汇率 API 接口返回的 CNY 汇率不是 float64 或者没有返回，API 接口异常。")
+	if len(data) == 0 {
+		return gerror.Newf("汇率来源 %v 没有返回任何汇率", p.Name())
 	}
-
-	// 数据库写入
-	_, err := dao.ConfigExchangeRate.Ctx(ctx).Data(g.Map{
-		"date": gtime.Date(),
-		"f":    "USD",
-		"t":    "CNY",
-		"rate": decimal.NewFromFloat(rate),
-	}).Insert()
-	if err != nil {
-		return decimal.Zero, gerror.Wrap(err, "数据库汇率写入失败")
+	if _, err = dao.ConfigExchangeRate.Ctx(ctx).Data(data).InsertIgnore(); err != nil {
+		return gerror.Wrap(err, "数据库汇率写入失败")
 	}
-
-	return decimal.NewFromFloat(rate), nil
+	return nil
 }
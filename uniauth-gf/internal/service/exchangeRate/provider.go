@@ -0,0 +1,177 @@
+package exchangeRate
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/shopspring/decimal"
+)
+
+// RateSet 一组以同一本位货币报价的汇率：1 Base = Rates[X] X
+type RateSet struct {
+	Base  string
+	Rates map[string]decimal.Decimal
+}
+
+// ExchangeRateProvider 汇率来源。取到的汇率会作为当天的汇率写入 config_exchange_rate。
+type ExchangeRateProvider interface {
+	// Name 汇率来源名称，用于日志
+	Name() string
+	// LatestRates 获取最新汇率
+	LatestRates(ctx context.Context) (*RateSet, error)
+}
+
+// ProviderConfig exchangeRate.providers 中的一项配置
+type ProviderConfig struct {
+	Type       string            `json:"type"`       // http | static | manual | fake
+	Name       string            `json:"name"`       // 名称，不填时使用 type
+	Url        string            `json:"url"`        // http：请求地址，可以用 ${ENV} 引用环境变量，避免把密钥写进配置
+	Base       string            `json:"base"`       // http / fake：本位货币，默认 USD
+	RatesField string            `json:"ratesField"` // http：响应中汇率所在的字段，支持 a.b 形式，默认 conversion_rates
+	Timeout    string            `json:"timeout"`    // http：请求超时，默认 3s
+	File       string            `json:"file"`       // static：汇率文件路径，支持 yaml / json / toml
+	Rates      map[string]string `json:"rates"`      // fake：固定汇率
+}
+
+var (
+	providerMu sync.RWMutex
+	provider   ExchangeRateProvider
+)
+
+// SetProvider 替换当前使用的汇率来源，传 nil 时下次使用会重新从配置构建。测试或离线环境可以设置为 FakeProvider。
+func SetProvider(p ExchangeRateProvider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	provider = p
+}
+
+// GetProvider 返回当前使用的汇率来源，第一次调用时根据配置构建。
+//
+// 配置 exchangeRate.providers 为汇率来源列表，按顺序依次尝试，前一个失败时使用下一个。
+// 没有配置时，如果设置了 exchangeRate.apiKey（或环境变量 EXCHANGERATE_APIKEY），使用 exchangerate-api.com，否则只使用数据库中手动上传的汇率。
+func GetProvider(ctx context.Context) ExchangeRateProvider {
+	providerMu.RLock()
+	p := provider
+	providerMu.RUnlock()
+	if p != nil {
+		return p
+	}
+
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	if provider == nil {
+		var err error
+		if provider, err = providerFromConfig(ctx); err != nil {
+			g.Log().Errorf(ctx, "汇率来源配置有误，将只使用数据库中的汇率: %v", err)
+			provider = ManualProvider{}
+		}
+	}
+	return provider
+}
+
+func providerFromConfig(ctx context.Context) (ExchangeRateProvider, error) {
+	var configs []*ProviderConfig
+	if err := g.Cfg().MustGet(ctx, "exchangeRate.providers").Scan(&configs); err != nil {
+		return nil, gerror.Wrap(err, "解析 exchangeRate.providers 失败")
+	}
+	if len(configs) == 0 {
+		apiKey := g.Cfg().MustGetWithEnv(ctx, "exchangeRate.apiKey").String()
+		if apiKey == "" {
+			g.Log().Warning(ctx, "没有配置 exchangeRate.providers 或 exchangeRate.apiKey，只使用数据库中手动上传的汇率，当天的汇率没有上传时使用最近一次的汇率")
+			return ManualProvider{}, nil
+		}
+		return &HTTPProvider{
+			ProviderName: "exchangerate-api",
+			Url:          "https://v6.exchangerate-api.com/v6/" + apiKey + "/latest/USD",
+			Base:         "USD",
+			RatesField:   "conversion_rates",
+		}, nil
+	}
+	providers := make(ChainProvider, 0, len(configs))
+	for i, config := range configs {
+		p, err := NewProvider(config)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "第 %d 个汇率来源配置有误", i+1)
+		}
+		providers = append(providers, p)
+	}
+	if len(providers) == 1 {
+		return providers[0], nil
+	}
+	return providers, nil
+}
+
+// NewProvider 根据配置构建一个汇率来源。
+func NewProvider(config *ProviderConfig) (ExchangeRateProvider, error) {
+	name := config.Name
+	if name == "" {
+		name = config.Type
+	}
+	switch config.Type {
+	case "http":
+		if config.Url == "" {
+			return nil, gerror.New("http 汇率来源缺少 url")
+		}
+		return &HTTPProvider{
+			ProviderName: name,
+			Url:          expandEnv(config.Url),
+			Base:         config.Base,
+			RatesField:   config.RatesField,
+			Timeout:      config.Timeout,
+		}, nil
+	case "static":
+		if config.File == "" {
+			return nil, gerror.New("static 汇率来源缺少 file")
+		}
+		return &StaticProvider{ProviderName: name, File: config.File}, nil
+	case "manual":
+		return ManualProvider{}, nil
+	case "fake":
+		rates, err := parseRates(config.Rates)
+		if err != nil {
+			return nil, err
+		}
+		return NewFakeProvider(config.Base, rates), nil
+	}
+	return nil, gerror.Newf("不支持的汇率来源类型：%v", config.Type)
+}
+
+// ChainProvider 按顺序尝试多个汇率来源，返回第一个成功的结果。
+type ChainProvider []ExchangeRateProvider
+
+func (c ChainProvider) Name() string {
+	names := make([]string, 0, len(c))
+	for _, p := range c {
+		names = append(names, p.Name())
+	}
+	return strings.Join(names, " -> ")
+}
+
+func (c ChainProvider) LatestRates(ctx context.Context) (*RateSet, error) {
+	var errs []string
+	for _, p := range c {
+		rates, err := p.LatestRates(ctx)
+		if err == nil {
+			return rates, nil
+		}
+		g.Log().Warningf(ctx, "汇率来源 %v 获取汇率失败，尝试下一个: %v", p.Name(), err)
+		errs = append(errs, p.Name()+": "+err.Error())
+	}
+	return nil, gerror.Newf("所有汇率来源都获取失败：%v", strings.Join(errs, "; "))
+}
+
+// parseRates 解析货币代码到汇率的映射，货币代码统一为大写。
+func parseRates(raw map[string]string) (map[string]decimal.Decimal, error) {
+	rates := make(map[string]decimal.Decimal, len(raw))
+	for currency, rateStr := range raw {
+		rate, err := decimal.NewFromString(rateStr)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "解析 %v 汇率失败", currency)
+		}
+		rates[strings.ToUpper(currency)] = rate
+	}
+	return rates, nil
+}
@@ -0,0 +1,78 @@
+package exchangeRate
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// HTTPProvider 从返回 JSON 的汇率 API 获取汇率。
+//
+// 响应中 RatesField 指向的对象需要是 {"CNY": 7.1, ...} 的形式。响应包含 result 字段时，要求其值为 success。
+type HTTPProvider struct {
+	ProviderName string
+	Url          string
+	Base         string // 默认 USD
+	RatesField   string // 默认 conversion_rates
+	Timeout      string // 默认 3s
+}
+
+func (p *HTTPProvider) Name() string {
+	return p.ProviderName
+}
+
+func (p *HTTPProvider) LatestRates(ctx context.Context) (*RateSet, error) {
+	timeout := 3 * time.Second
+	if p.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(p.Timeout); err != nil {
+			return nil, gerror.Wrapf(err, "汇率来源 %v 的 timeout 无效", p.Name())
+		}
+	}
+	resp, err := g.Client().Timeout(timeout).Get(ctx, p.Url)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "请求汇率来源 %v 失败", p.Name())
+	}
+	defer resp.Close()
+	body := resp.ReadAll()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gerror.Newf("汇率来源 %v 返回非 2xx 状态码：%d", p.Name(), resp.StatusCode)
+	}
+
+	j, err := gjson.DecodeToJson(body)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "汇率接口返回信息反序列化失败。[DEBUG]原始响应：%s", body)
+	}
+	if result := j.Get("result"); !result.IsNil() && result.String() != "success" {
+		return nil, gerror.Newf("汇率来源 %v 返回的 result 不是 success，API 接口异常。", p.Name())
+	}
+	ratesField := p.RatesField
+	if ratesField == "" {
+		ratesField = "conversion_rates"
+	}
+	raw := j.Get(ratesField).MapStrStr()
+	if len(raw) == 0 {
+		return nil, gerror.Newf("汇率来源 %v 没有返回 %v，API 接口异常。", p.Name(), ratesField)
+	}
+	rates, err := parseRates(raw)
+	if err != nil {
+		return nil, err
+	}
+	base := p.Base
+	if base == "" {
+		base = "USD"
+	}
+	return &RateSet{Base: strings.ToUpper(base), Rates: rates}, nil
+}
+
+// expandEnv 展开 ${ENV} 形式的环境变量。
+func expandEnv(s string) string {
+	return os.Expand(s, func(key string) string {
+		return os.Getenv(key)
+	})
+}
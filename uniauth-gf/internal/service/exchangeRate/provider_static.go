@@ -0,0 +1,86 @@
+package exchangeRate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/shopspring/decimal"
+)
+
+// StaticProvider 从本地文件读取汇率，适合离线部署。文件格式：
+//
+//	base: USD
+//	rates:
+//	  CNY: 7.12
+//	  EUR: 0.92
+//
+// 每次获取时都会重新读取文件，修改文件后不需要重启。
+type StaticProvider struct {
+	ProviderName string
+	File         string
+}
+
+func (p *StaticProvider) Name() string {
+	return p.ProviderName
+}
+
+func (p *StaticProvider) LatestRates(ctx context.Context) (*RateSet, error) {
+	j, err := gjson.Load(p.File)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "读取汇率文件 %v 失败", p.File)
+	}
+	base := j.Get("base").String()
+	if base == "" {
+		return nil, gerror.Newf("汇率文件 %v 缺少 base", p.File)
+	}
+	rates, err := parseRates(j.Get("rates").MapStrStr())
+	if err != nil {
+		return nil, gerror.Wrapf(err, "解析汇率文件 %v 失败", p.File)
+	}
+	if len(rates) == 0 {
+		return nil, gerror.Newf("汇率文件 %v 没有任何汇率", p.File)
+	}
+	return &RateSet{Base: strings.ToUpper(base), Rates: rates}, nil
+}
+
+// ManualProvider 不从外部获取汇率，只使用管理员通过接口上传到数据库的汇率。
+type ManualProvider struct{}
+
+func (ManualProvider) Name() string {
+	return "manual"
+}
+
+func (ManualProvider) LatestRates(ctx context.Context) (*RateSet, error) {
+	return nil, gerror.New("手动模式不从外部获取汇率，请通过接口上传汇率")
+}
+
+// FakeProvider 总是返回固定汇率，用于测试和无法访问外网的环境。
+type FakeProvider struct {
+	base  string
+	rates map[string]decimal.Decimal
+}
+
+// NewFakeProvider 创建固定汇率的来源。base 为空时为 USD；rates 为空时使用 1 USD = 7 CNY。
+func NewFakeProvider(base string, rates map[string]decimal.Decimal) *FakeProvider {
+	if base == "" {
+		base = "USD"
+	}
+	if len(rates) == 0 {
+		rates = map[string]decimal.Decimal{"CNY": decimal.NewFromInt(7)}
+	}
+	return &FakeProvider{base: strings.ToUpper(base), rates: rates}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) LatestRates(ctx context.Context) (*RateSet, error) {
+	rates := make(map[string]decimal.Decimal, len(p.rates))
+	for currency, rate := range p.rates {
+		rates[strings.ToUpper(currency)] = rate
+	}
+	return &RateSet{Base: p.base, Rates: rates}, nil
+}
@@ -0,0 +1,157 @@
+package exchangeRate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// BaseCurrency 返回计费的本位货币，配额池余额和计费记录的 cost 都以本位货币计。默认为 CNY。
+func BaseCurrency(ctx context.Context) string {
+	return strings.ToUpper(g.Cfg().MustGetWithEnv(ctx, "billing.baseCurrency", "CNY").String())
+}
+
+// lookupRate 查询不晚于 date 的最近一条 1 f = ? t 汇率，没有时 found 为 false。
+func lookupRate(ctx context.Context, f, t string, date *gtime.Time) (rate decimal.Decimal, rateDate *gtime.Time, found bool, err error) {
+	var record *entity.ConfigExchangeRate
+	if err = dao.ConfigExchangeRate.Ctx(ctx).
+		Where("f = ?", f).
+		Where("t = ?", t).
+		WhereLTE("date", date.Format("Y-m-d")).
+		OrderDesc("date").
+		Limit(1).
+		Scan(&record); err != nil {
+		return decimal.Zero, nil, false, gerror.Wrapf(err, "查询 %v->%v 汇率失败", f, t)
+	}
+	if record == nil {
+		return decimal.Zero, nil, false, nil
+	}
+	return record.Rate, record.Date, true, nil
+}
+
+// lookupPair 依次尝试正向汇率和反向汇率。
+func lookupPair(ctx context.Context, f, t string, date *gtime.Time) (rate decimal.Decimal, rateDate *gtime.Time, found bool, err error) {
+	if rate, rateDate, found, err = lookupRate(ctx, f, t, date); err != nil || found {
+		return
+	}
+	if rate, rateDate, found, err = lookupRate(ctx, t, f, date); err != nil || !found {
+		return
+	}
+	if rate.IsZero() {
+		return decimal.Zero, nil, false, gerror.Newf("%v->%v 汇率为 0，无法换算", t, f)
+	}
+	return decimal.NewFromInt(1).Div(rate), rateDate, true, nil
+}
+
+// GetRateAt 获取 date 当天 1 f = ? t 的汇率，返回汇率和汇率实际所属的日期。
+//
+// 依次尝试正向汇率、反向汇率，以及经 USD 的交叉汇率。当天没有汇率时使用最近一个更早日期的汇率，
+// 但不能早于 exchangeRate.maxStaleness（默认 168h，0 表示不限制）；
+// 查询今天的汇率时会先向汇率来源请求一次，请求失败时同样回退到更早的汇率，并在 1 分钟内不再请求。
+func GetRateAt(ctx context.Context, f, t string, date *gtime.Time) (rate decimal.Decimal, rateDate *gtime.Time, err error) {
+	f, t = strings.ToUpper(f), strings.ToUpper(t)
+	if f == t {
+		return decimal.NewFromInt(1), date, nil
+	}
+	if date == nil {
+		date = gtime.Now()
+	}
+	day := date.Format("Y-m-d")
+	isToday := day == gtime.Now().Format("Y-m-d")
+	pivotPair := f == pivotCurrency || t == pivotCurrency
+
+	resolve := func() (decimal.Decimal, *gtime.Time, bool, error) {
+		rate, rateDate, found, err := lookupPair(ctx, f, t, date)
+		if err != nil || found || pivotPair {
+			return rate, rateDate, found, err
+		}
+		// 经中间货币交叉换算
+		fUsd, fDate, fFound, err := lookupPair(ctx, f, pivotCurrency, date)
+		if err != nil || !fFound {
+			return decimal.Zero, nil, false, err
+		}
+		usdT, tDate, tFound, err := lookupPair(ctx, pivotCurrency, t, date)
+		if err != nil || !tFound {
+			return decimal.Zero, nil, false, err
+		}
+		// 取两个汇率中较早的日期
+		if tDate.Before(fDate) {
+			fDate = tDate
+		}
+		return fUsd.Mul(usdT), fDate, true, nil
+	}
+
+	rate, rateDate, found, err := resolve()
+	if err != nil {
+		return decimal.Zero, nil, err
+	}
+	if isToday && (!found || rateDate.Format("Y-m-d") != day) {
+		// 今天的汇率还没有入库，向汇率来源请求一次
+		if fetched, apiErr := fetchTodayRates(ctx); apiErr != nil {
+			g.Log().Warningf(ctx, "从汇率来源获取今天的汇率失败，将使用最近一次的汇率: %v", apiErr)
+		} else if fetched {
+			if rate, rateDate, found, err = resolve(); err != nil {
+				return decimal.Zero, nil, err
+			}
+		}
+	}
+	if !found {
+		return decimal.Zero, nil, gerror.Newf("找不到 %v 及之前的 %v->%v 汇率", day, f, t)
+	}
+	maxStaleness := g.Cfg().MustGetWithEnv(ctx, "exchangeRate.maxStaleness", "168h").Duration()
+	if maxStaleness > 0 && date.Sub(rateDate) > maxStaleness {
+		return decimal.Zero, nil, gerror.Newf("最近的 %v->%v 汇率是 %v 的，超过了允许的时效 %v", f, t, rateDate.Format("Y-m-d"), maxStaleness)
+	}
+	return rate, rateDate, nil
+}
+
+// Convert 按 date 当天的汇率把 amount 从 from 换算为 to，返回换算后的金额和使用的汇率。
+func Convert(ctx context.Context, amount decimal.Decimal, from, to string, date *gtime.Time) (converted decimal.Decimal, rate decimal.Decimal, err error) {
+	rate, _, err = GetRateAt(ctx, from, to, date)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	return amount.Mul(rate), rate, nil
+}
+
+// UpsertRates 批量写入汇率，同一日期、同一货币对已有的汇率会被覆盖。
+func UpsertRates(ctx context.Context, rates []*entity.ConfigExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	data := make(g.List, 0, len(rates))
+	for _, rate := range rates {
+		f, t := strings.ToUpper(rate.F), strings.ToUpper(rate.T)
+		if f == t {
+			return gerror.Newf("本位货币和标的货币不能相同：%v", f)
+		}
+		if !rate.Rate.IsPositive() {
+			return gerror.Newf("%v %v->%v 汇率必须大于 0", rate.Date.Format("Y-m-d"), f, t)
+		}
+		data = append(data, g.Map{
+			"date": rate.Date.Format("Y-m-d"),
+			"f":    f,
+			"t":    t,
+			"rate": rate.Rate,
+		})
+	}
+	if _, err := dao.ConfigExchangeRate.Ctx(ctx).
+		Data(data).
+		OnConflict("date", "f", "t").
+		OnDuplicate(g.Map{
+			"rate":       gdb.Raw("EXCLUDED.rate"),
+			"created_at": gdb.Raw("NOW()"),
+		}).
+		Save(); err != nil {
+		return gerror.Wrap(err, "写入汇率失败")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package operator 从请求上下文中解析当前操作者的身份和授权范围，审计日志、配额池账本和按域隔离的管理接口共用。
+package operator
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"uniauth-gf/internal/consts"
+)
+
+// ActorSystem 没有 HTTP 请求的后台流程（定时任务、懒刷新等）使用的操作者
+const ActorSystem = "system"
+
+// Actor 推断当前操作者。
+//
+// HTTP 请求中优先使用已登录的管理员（记为 admin:<username>），其次是持有 API Key 的服务（记为 svc:<service>），
+// 再次是调用方自报的 X-Operator 请求头，否则记为 "api:<请求路径>"。X-Operator 可以伪造，只在前两者都没有时使用。
+// 没有 HTTP 请求的后台流程记为 ActorSystem。
+func Actor(ctx context.Context) string {
+	r := g.RequestFromCtx(ctx)
+	if r == nil {
+		return ActorSystem
+	}
+	if username := r.GetCtxVar(consts.CtxKeyAdminUsername).String(); username != "" {
+		return "admin:" + username
+	}
+	if service := r.GetCtxVar(consts.CtxKeyServiceName).String(); service != "" {
+		return "svc:" + service
+	}
+	if operator := r.GetHeader("X-Operator"); operator != "" {
+		return operator
+	}
+	return "api:" + r.URL.Path
+}
+
+// AdminDomain 返回管理员鉴权中间件授权的 Casbin 域。
+//
+// 只有经过 AdminAuthMiddleware 的请求才有授权域，ok 为 false 表示不是管理员请求（服务调用或后台流程），调用方不需要按域隔离。
+func AdminDomain(ctx context.Context) (dom string, ok bool) {
+	r := g.RequestFromCtx(ctx)
+	if r == nil {
+		return "", false
+	}
+	dom = r.GetCtxVar(consts.CtxKeyAdminDomain).String()
+	return dom, dom != ""
+}
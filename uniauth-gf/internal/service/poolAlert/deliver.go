@@ -0,0 +1,186 @@
+package poolAlert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/google/uuid"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// 投递状态
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook 请求头
+const (
+	HeaderEventId   = "X-UniAuth-Event-Id"
+	HeaderTimestamp = "X-UniAuth-Timestamp"
+	HeaderSignature = "X-UniAuth-Signature"
+)
+
+const (
+	claimBatchSize = 20
+	// 领取后的租约时长，实例在投递过程中崩溃时，租约到期后其他实例会重新投递
+	claimLease = 2 * time.Minute
+)
+
+// HTTPClient 投递 Webhook 使用的 HTTP 客户端，可以替换为指向本地替身服务的客户端。
+var HTTPClient = &http.Client{}
+
+// 领取到期的待投递记录。SKIP LOCKED 保证多个实例不会领取到同一条记录。
+const claimSql = `
+UPDATE quotapool_alert_delivery SET next_attempt_at = ?, updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM quotapool_alert_delivery
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY id
+    LIMIT ?
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// Sign 计算 Webhook 签名：HMAC-SHA256(secret, "<timestamp>.<body>")，十六进制编码。
+//
+// 接收方应使用同样的方式计算签名，并用常量时间比较请求头中的 sha256=<signature>，同时校验时间戳避免重放。
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send 向 Webhook 发送一次签名后的请求，返回 HTTP 状态码。非 2xx 响应视为失败。
+func Send(ctx context.Context, url, secret, eventId string, body []byte) (status int, err error) {
+	timeout := g.Cfg().MustGetWithEnv(ctx, "alert.webhookTimeout", "10s").Duration()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, gerror.Wrap(err, "构造 Webhook 请求失败")
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventId, eventId)
+	req.Header.Set(HeaderTimestamp, fmt.Sprint(timestamp))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(secret, timestamp, body))
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return 0, gerror.Wrap(err, "请求 Webhook 失败")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, gerror.Newf("Webhook 返回非 2xx 状态码：%d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff 第 attempts 次失败后的重试间隔：从 alert.retryBaseInterval 开始指数增长，不超过 alert.retryMaxInterval。
+func backoff(ctx context.Context, attempts int) time.Duration {
+	base := g.Cfg().MustGetWithEnv(ctx, "alert.retryBaseInterval", "30s").Duration()
+	maxInterval := g.Cfg().MustGetWithEnv(ctx, "alert.retryMaxInterval", "1h").Duration()
+	interval := time.Duration(float64(base) * math.Pow(2, float64(attempts-1)))
+	if interval <= 0 || interval > maxInterval {
+		return maxInterval
+	}
+	return interval
+}
+
+// DeliverPending 投递所有到期的告警，返回本次投递成功的条数。
+func DeliverPending(ctx context.Context) (delivered int, err error) {
+	maxAttempts := g.Cfg().MustGetWithEnv(ctx, "alert.maxAttempts", 8).Int()
+	for {
+		var deliveries []*entity.QuotapoolAlertDelivery
+		if err = dao.QuotapoolAlertDelivery.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			return tx.GetScan(&deliveries, claimSql, gtime.Now().Add(claimLease), claimBatchSize)
+		}); err != nil {
+			return delivered, gerror.Wrap(err, "领取待投递的告警失败")
+		}
+		if len(deliveries) == 0 {
+			return delivered, nil
+		}
+		for _, delivery := range deliveries {
+			if deliverOne(ctx, delivery, maxAttempts) {
+				delivered++
+			}
+		}
+	}
+}
+
+// deliverOne 投递一条告警并记录结果，投递成功时返回 true。
+func deliverOne(ctx context.Context, delivery *entity.QuotapoolAlertDelivery, maxAttempts int) bool {
+	var (
+		status int
+		err    error
+		rule   *entity.QuotapoolAlertRule
+	)
+	if err = dao.QuotapoolAlertRule.Ctx(ctx).WherePri(delivery.RuleId).Scan(&rule); err == nil && rule == nil {
+		err = gerror.Newf("告警规则 %v 已被删除", delivery.RuleId)
+	}
+	if err == nil {
+		status, err = Send(ctx, delivery.WebhookUrl, rule.Secret, delivery.EventId, []byte(delivery.Payload.MustToJsonString()))
+	}
+
+	attempts := delivery.Attempts + 1
+	data := g.Map{
+		"attempts":        attempts,
+		"response_status": status,
+		"updated_at":      gtime.Now(),
+	}
+	switch {
+	case err == nil:
+		data["status"] = DeliveryDelivered
+		data["delivered_at"] = gtime.Now()
+		data["last_error"] = nil
+	case rule == nil || attempts >= maxAttempts:
+		data["status"] = DeliveryFailed
+		data["last_error"] = err.Error()
+	default:
+		data["next_attempt_at"] = gtime.Now().Add(backoff(ctx, attempts))
+		data["last_error"] = err.Error()
+	}
+	if _, updateErr := dao.QuotapoolAlertDelivery.Ctx(ctx).WherePri(delivery.Id).Data(data).Update(); updateErr != nil {
+		g.Log().Errorf(ctx, "更新告警投递记录 %v 失败: %v", delivery.Id, updateErr)
+	}
+	if err != nil {
+		g.Log().Warningf(ctx, "投递告警 %v 到 %v 失败（第 %d 次）: %v", delivery.EventId, delivery.WebhookUrl, attempts, err)
+	}
+	return err == nil
+}
+
+// SendTest 向规则的 Webhook 同步发送一条测试事件，不写投递记录。
+func SendTest(ctx context.Context, rule *entity.QuotapoolAlertRule) (eventId string, status int, err error) {
+	eventId = uuid.New().String()
+	body := gjson.New(g.Map{
+		"eventId":       eventId,
+		"type":          EventTest,
+		"quotaPoolName": rule.QuotaPoolName,
+		"ruleId":        rule.Id,
+		"thresholdType": rule.ThresholdType,
+		"threshold":     rule.Threshold,
+		"triggeredAt":   gtime.Now(),
+	}).MustToJson()
+	status, err = Send(ctx, rule.WebhookUrl, rule.Secret, eventId, body)
+	return
+}
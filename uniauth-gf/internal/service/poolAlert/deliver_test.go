@@ -0,0 +1,181 @@
+package poolAlert
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcfg"
+)
+
+// 测试不读取 manifest/config 中的加密配置，使用下面的告警配置，其余配置项取默认值
+const testConfig = `
+alert:
+  webhookTimeout: 5s
+  retryBaseInterval: 30s
+  retryMaxInterval: 1h
+`
+
+func TestMain(m *testing.M) {
+	adapter, err := gcfg.NewAdapterContent(testConfig)
+	if err != nil {
+		panic(err)
+	}
+	g.Cfg().SetAdapter(adapter)
+	os.Exit(m.Run())
+}
+
+// webhookRecorder 本地替身 Webhook，按 statuses 依次返回状态码，之后都返回 200，并记录收到的请求
+type webhookRecorder struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func (w *webhookRecorder) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.requests = append(w.requests, recordedRequest{header: r.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(w.statuses) > 0 {
+		status, w.statuses = w.statuses[0], w.statuses[1:]
+	}
+	rw.WriteHeader(status)
+}
+
+// newWebhook 启动替身 Webhook，并在测试期间把 HTTPClient 替换为它的客户端
+func newWebhook(t *testing.T, statuses ...int) (*webhookRecorder, string) {
+	t.Helper()
+	recorder := &webhookRecorder{statuses: statuses}
+	srv := httptest.NewServer(recorder)
+	t.Cleanup(srv.Close)
+	client := HTTPClient
+	HTTPClient = srv.Client()
+	t.Cleanup(func() { HTTPClient = client })
+	return recorder, srv.URL
+}
+
+func TestSendDelivers(t *testing.T) {
+	recorder, url := newWebhook(t)
+	body := []byte(`{"eventId":"evt-1","type":"low_balance"}`)
+
+	status, err := Send(context.Background(), url, "secret", "evt-1", body)
+	if err != nil {
+		t.Fatalf("Send 失败: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Fatalf("状态码为 %d，期望 200", status)
+	}
+	if len(recorder.requests) != 1 {
+		t.Fatalf("Webhook 收到 %d 次请求，期望 1 次", len(recorder.requests))
+	}
+	got := recorder.requests[0]
+	if string(got.body) != string(body) {
+		t.Errorf("请求体为 %s，期望 %s", got.body, body)
+	}
+	if ct := got.header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type 为 %q", ct)
+	}
+	if id := got.header.Get(HeaderEventId); id != "evt-1" {
+		t.Errorf("%v 为 %q，期望 evt-1", HeaderEventId, id)
+	}
+}
+
+func TestSendSignature(t *testing.T) {
+	recorder, url := newWebhook(t)
+	body := []byte(`{"eventId":"evt-2"}`)
+	before := time.Now().Unix()
+
+	if _, err := Send(context.Background(), url, "s3cret", "evt-2", body); err != nil {
+		t.Fatalf("Send 失败: %v", err)
+	}
+	got := recorder.requests[0]
+	timestamp, err := strconv.ParseInt(got.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%v 无法解析: %v", HeaderTimestamp, err)
+	}
+	if timestamp < before || timestamp > time.Now().Unix() {
+		t.Errorf("%v 为 %d，不在发送时间范围内", HeaderTimestamp, timestamp)
+	}
+
+	// 按接收方的方式校验签名
+	want := "sha256=" + Sign("s3cret", timestamp, got.body)
+	if sig := got.header.Get(HeaderSignature); !hmac.Equal([]byte(sig), []byte(want)) {
+		t.Errorf("%v 为 %q，期望 %q", HeaderSignature, sig, want)
+	}
+	if sig := got.header.Get(HeaderSignature); hmac.Equal([]byte(sig), []byte("sha256="+Sign("other", timestamp, got.body))) {
+		t.Error("使用错误的密钥也能通过签名校验")
+	}
+	if Sign("s3cret", timestamp, got.body) == Sign("s3cret", timestamp+1, got.body) {
+		t.Error("签名没有覆盖时间戳")
+	}
+}
+
+func TestSendRetriesOn5xx(t *testing.T) {
+	recorder, url := newWebhook(t, http.StatusInternalServerError, http.StatusBadGateway)
+	body := []byte(`{"eventId":"evt-3"}`)
+
+	// 前两次 5xx 视为失败并返回状态码，投递记录会按 backoff 安排重试
+	var intervals []time.Duration
+	for attempts := 1; ; attempts++ {
+		status, err := Send(context.Background(), url, "secret", "evt-3", body)
+		if err == nil {
+			if status != http.StatusOK {
+				t.Fatalf("第 %d 次投递状态码为 %d，期望 200", attempts, status)
+			}
+			break
+		}
+		if status < 500 {
+			t.Fatalf("第 %d 次投递失败的状态码为 %d，期望 5xx: %v", attempts, status, err)
+		}
+		if attempts >= 3 {
+			t.Fatalf("第 %d 次投递仍然失败: %v", attempts, err)
+		}
+		intervals = append(intervals, backoff(context.Background(), attempts))
+	}
+
+	if len(recorder.requests) != 3 {
+		t.Fatalf("Webhook 收到 %d 次请求，期望 3 次", len(recorder.requests))
+	}
+	// 重试使用同一个事件 ID，接收方可以据此去重
+	for i, req := range recorder.requests {
+		if id := req.header.Get(HeaderEventId); id != "evt-3" {
+			t.Errorf("第 %d 次请求的 %v 为 %q", i+1, HeaderEventId, id)
+		}
+	}
+	if want := []time.Duration{30 * time.Second, time.Minute}; len(intervals) != len(want) || intervals[0] != want[0] || intervals[1] != want[1] {
+		t.Errorf("重试间隔为 %v，期望 %v", intervals, want)
+	}
+}
+
+func TestBackoffCapped(t *testing.T) {
+	ctx := context.Background()
+	prev := time.Duration(0)
+	for attempts := 1; attempts <= 20; attempts++ {
+		interval := backoff(ctx, attempts)
+		if interval < prev {
+			t.Fatalf("第 %d 次失败后的重试间隔 %v 小于上一次的 %v", attempts, interval, prev)
+		}
+		if interval > time.Hour {
+			t.Fatalf("第 %d 次失败后的重试间隔 %v 超过了上限 1h", attempts, interval)
+		}
+		prev = interval
+	}
+	if prev != time.Hour {
+		t.Errorf("多次失败后的重试间隔为 %v，期望达到上限 1h", prev)
+	}
+}
@@ -0,0 +1,114 @@
+package poolAlert
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// 阈值类型
+const (
+	ThresholdAbsolute = "absolute" // 可用余额低于固定值
+	ThresholdPercent  = "percent"  // 可用余额低于定期配额的百分比
+)
+
+// 告警事件类型
+const (
+	EventLowBalance = "quota_pool.low_balance" // 余额跌破阈值
+	EventTest       = "quota_pool.test"        // 手动触发的测试事件
+)
+
+// ThresholdValue 计算告警规则对应的余额阈值。
+func ThresholdValue(rule *entity.QuotapoolAlertRule, regularQuota decimal.Decimal) decimal.Decimal {
+	if rule.ThresholdType == ThresholdPercent {
+		return regularQuota.Mul(rule.Threshold).Div(decimal.NewFromInt(100))
+	}
+	return rule.Threshold
+}
+
+// Evaluate 根据配额池变动后的可用余额评估告警规则，跌破阈值时写入一条待投递的 Webhook。
+//
+// 需要在更新余额的事务中调用，告警状态和余额一起提交或回滚。每条规则每次跌破阈值只告警一次，
+// 余额回到阈值以上后重新生效。
+func Evaluate(ctx context.Context, quotaPoolName string, balanceBefore, balanceAfter decimal.Decimal) error {
+	var rules []*entity.QuotapoolAlertRule
+	if err := dao.QuotapoolAlertRule.Ctx(ctx).
+		Where("quota_pool_name = ?", quotaPoolName).
+		Where("enabled = ?", true).
+		LockUpdate().
+		Scan(&rules); err != nil {
+		return gerror.Wrap(err, "查询配额池告警规则失败")
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	regularQuotaRaw, err := dao.QuotapoolQuotaPool.Ctx(ctx).
+		Fields("regular_quota").
+		Where("quota_pool_name = ?", quotaPoolName).
+		Value()
+	if err != nil {
+		return gerror.Wrap(err, "查询配额池定期配额失败")
+	}
+	regularQuota, err := decimal.NewFromString(regularQuotaRaw.String())
+	if err != nil {
+		return gerror.Wrap(err, "解析配额池定期配额失败")
+	}
+
+	for _, rule := range rules {
+		threshold := ThresholdValue(rule, regularQuota)
+		below := balanceAfter.LessThan(threshold)
+		switch {
+		case below && !rule.Triggered:
+			now := gtime.Now()
+			eventId := uuid.New().String()
+			payload := gjson.New(g.Map{
+				"eventId":        eventId,
+				"type":           EventLowBalance,
+				"quotaPoolName":  quotaPoolName,
+				"ruleId":         rule.Id,
+				"thresholdType":  rule.ThresholdType,
+				"threshold":      rule.Threshold,
+				"thresholdValue": threshold,
+				"balanceBefore":  balanceBefore,
+				"balance":        balanceAfter,
+				"overspent":      balanceAfter.IsNegative(),
+				"triggeredAt":    now,
+			})
+			if _, err = dao.QuotapoolAlertDelivery.Ctx(ctx).Data(g.Map{
+				"event_id":        eventId,
+				"rule_id":         rule.Id,
+				"quota_pool_name": quotaPoolName,
+				"webhook_url":     rule.WebhookUrl,
+				"payload":         payload,
+				"status":          DeliveryPending,
+				"next_attempt_at": now,
+			}).Insert(); err != nil {
+				return gerror.Wrapf(err, "写入告警投递记录失败。规则 ID：%v", rule.Id)
+			}
+			if _, err = dao.QuotapoolAlertRule.Ctx(ctx).WherePri(rule.Id).Data(g.Map{
+				"triggered":         true,
+				"last_triggered_at": now,
+				"updated_at":        now,
+			}).Update(); err != nil {
+				return gerror.Wrapf(err, "更新告警规则触发状态失败。规则 ID：%v", rule.Id)
+			}
+		case !below && rule.Triggered:
+			// 余额回到阈值以上，重新生效
+			if _, err = dao.QuotapoolAlertRule.Ctx(ctx).WherePri(rule.Id).Data(g.Map{
+				"triggered":  false,
+				"updated_at": gtime.Now(),
+			}).Update(); err != nil {
+				return gerror.Wrapf(err, "重置告警规则触发状态失败。规则 ID：%v", rule.Id)
+			}
+		}
+	}
+	return nil
+}
@@ -21,6 +21,21 @@ func Create(ctx context.Context, newQuotaPoolInfo *entity.QuotapoolQuotaPool) (e
 		err = gerror.Newf("cronCycle 无效: %v", cronErr)
 		return
 	}
+	if newQuotaPoolInfo.UserLimitCronCycle != "" {
+		if _, cronErr := cron.ParseStandard(newQuotaPoolInfo.UserLimitCronCycle); cronErr != nil {
+			err = gerror.Newf("userLimitCronCycle 无效: %v", cronErr)
+			return
+		}
+	}
+	if err = ValidateRollover(newQuotaPoolInfo.RolloverPolicy); err != nil {
+		return
+	}
+	if newQuotaPoolInfo.Domain == "" {
+		newQuotaPoolInfo.Domain = casbin.DefaultDomain
+	}
+	if err = casbin.CheckDomain(newQuotaPoolInfo.Domain); err != nil {
+		return
+	}
 	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
 		// 根据 userinfos 规则，筛选出符合规则的用户
 		var filterGroup *v1.FilterGroup
@@ -43,16 +58,15 @@ func Create(ctx context.Context, newQuotaPoolInfo *entity.QuotapoolQuotaPool) (e
 			return gerror.Wrap(err, "新增配额池失败")
 		}
 
-		// 建立 casbin 角色继承
+		// 在配额池所属的域中建立 casbin 角色继承
 		groupings := make([][]string, 0, len(filterRes.UserUpns))
 		for _, upn := range filterRes.UserUpns {
-			groupings = append(groupings, []string{upn, newQuotaPoolInfo.QuotaPoolName})
+			groupings = append(groupings, []string{upn, newQuotaPoolInfo.QuotaPoolName, newQuotaPoolInfo.Domain})
 		}
-		e := casbin.GetEnforcer()
 		if len(groupings) != 0 {
-			if noDuplicate, err := e.AddGroupingPoliciesEx(groupings); err != nil {
+			if added, err := casbin.AddGroupingPolicies(ctx, "quotaPool.Create", groupings, true); err != nil {
 				return gerror.Wrap(err, "Casbin 批量新增配额池角色失败")
-			} else if !noDuplicate {
+			} else if len(added) != len(groupings) {
 				g.Log().Warningf(ctx, "新增配额池 %v 的角色时，发现重复角色。", newQuotaPoolInfo.QuotaPoolName)
 			}
 		}
@@ -62,4 +76,4 @@ func Create(ctx context.Context, newQuotaPoolInfo *entity.QuotapoolQuotaPool) (e
 		return gerror.Wrapf(err, "新增配额池 %v 事务失败", newQuotaPoolInfo.QuotaPoolName)
 	}
 	return nil
-}
\ No newline at end of file
+}
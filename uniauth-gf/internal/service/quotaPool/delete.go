@@ -13,6 +13,10 @@ import (
 
 func Delete(ctx context.Context, quotaPoolName string) error {
 	err := dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		domain, err := dao.QuotapoolQuotaPool.Ctx(ctx).Where("quota_pool_name = ?", quotaPoolName).Value(dao.QuotapoolQuotaPool.Columns().Domain)
+		if err != nil {
+			return gerror.WrapCode(gcode.CodeDbOperationError, err, "查询配额池所属域失败")
+		}
 		if sqlRes, delErr := dao.QuotapoolQuotaPool.Ctx(ctx).Where("quota_pool_name = ?", quotaPoolName).Delete(); delErr != nil {
 			return gerror.WrapCode(gcode.CodeDbOperationError, delErr, "删除配额池失败")
 		} else if eftRow, err := sqlRes.RowsAffected(); eftRow == 0 {
@@ -23,12 +27,12 @@ func Delete(ctx context.Context, quotaPoolName string) error {
 
 		// 清理 Casbin 中的相关规则
 		e := casbin.GetEnforcer()
-		// 获取该配额池的所有用户映射关系
-		userUpns, err := e.GetUsersForRole(quotaPoolName)
+		// 获取该配额池在所属域中的所有用户映射关系
+		userUpns, err := e.GetUsersForRole(quotaPoolName, domain.String())
 		if err != nil {
 			return gerror.Wrap(err, "查询配额池用户失败")
 		}
-		autoQPs, err := e.GetRolesForUser(quotaPoolName)
+		autoQPs, err := e.GetRolesForUser(quotaPoolName, domain.String())
 		if err != nil {
 			return gerror.Wrap(err, "查询配额池角色失败")
 		}
@@ -36,13 +40,13 @@ func Delete(ctx context.Context, quotaPoolName string) error {
 		if len(userUpns) + len(autoQPs) > 0 {
 			var policiesToDelete [][]string
 			for _, upn := range userUpns {
-				policiesToDelete = append(policiesToDelete, []string{upn, quotaPoolName})
+				policiesToDelete = append(policiesToDelete, []string{upn, quotaPoolName, domain.String()})
 			}
 			for _, autoQP := range autoQPs {
-				policiesToDelete = append(policiesToDelete, []string{quotaPoolName, autoQP})
+				policiesToDelete = append(policiesToDelete, []string{quotaPoolName, autoQP, domain.String()})
 			}
 
-			if _, err := e.RemoveGroupingPolicies(policiesToDelete); err != nil {
+			if _, err := casbin.RemoveGroupingPolicies(ctx, "quotaPool.Delete", policiesToDelete); err != nil {
 				return gerror.Wrapf(err, "删除配额池用户组继承关系失败: %v", policiesToDelete)
 			}
 		}
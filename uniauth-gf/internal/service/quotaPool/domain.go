@@ -0,0 +1,64 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/operator"
+)
+
+// GetDomain 查询配额池所属的 Casbin 域。配额池的用户继承关系和权限规则都在这个域中。
+func GetDomain(ctx context.Context, quotaPoolName string) (string, error) {
+	domain, err := dao.QuotapoolQuotaPool.Ctx(ctx).
+		Where("quota_pool_name = ?", quotaPoolName).
+		Value(dao.QuotapoolQuotaPool.Columns().Domain)
+	if err != nil {
+		return "", gerror.Wrapf(err, "查询配额池 %v 所属的域失败", quotaPoolName)
+	}
+	if domain.IsEmpty() {
+		return "", gerror.Newf("找不到配额池 %v", quotaPoolName)
+	}
+	return domain.String(), nil
+}
+
+// CheckAdminDomain 检查配额池都属于管理员授权的域。
+//
+// 管理员鉴权中间件只按请求参数 dom 授权，配额池的写操作必须再确认目标配额池确实在这个域中，否则拒绝。不是管理员请求时不检查。
+func CheckAdminDomain(ctx context.Context, quotaPoolNames ...string) error {
+	dom, ok := operator.AdminDomain(ctx)
+	if !ok {
+		return nil
+	}
+	for _, name := range quotaPoolNames {
+		domain, err := GetDomain(ctx, name)
+		if err != nil {
+			return err
+		}
+		if domain != dom {
+			return gerror.NewCodef(gcode.CodeNotAuthorized, "配额池 %v 属于域 %v，不在授权的域 %v 中", name, domain, dom)
+		}
+	}
+	return nil
+}
+
+// ScopeAdminDomain 把配额池查询限制在管理员授权的域中。不是管理员请求时原样返回。
+func ScopeAdminDomain(ctx context.Context, model *gdb.Model) *gdb.Model {
+	if dom, ok := operator.AdminDomain(ctx); ok {
+		return model.Where(dao.QuotapoolQuotaPool.Columns().Domain, dom)
+	}
+	return model
+}
+
+// ScopeAdminDomainSource 把按配额池名称关联的查询（例如账单的 source 字段）限制在管理员授权域中的配额池。不是管理员请求时原样返回。
+func ScopeAdminDomainSource(ctx context.Context, model *gdb.Model, column string) *gdb.Model {
+	if dom, ok := operator.AdminDomain(ctx); ok {
+		return model.Where(column+" IN ?", dao.QuotapoolQuotaPool.Ctx(ctx).
+			Fields(dao.QuotapoolQuotaPool.Columns().QuotaPoolName).
+			Where(dao.QuotapoolQuotaPool.Columns().Domain, dom))
+	}
+	return model
+}
@@ -33,25 +33,60 @@ func Edit(ctx context.Context, editInfo g.Map) (err error) {
 			return
 		}
 	}
+	// 个人限额统计周期允许为空，为空时沿用 cronCycle
+	if userCronExpr, ok := editInfo["userLimitCronCycle"]; ok && userCronExpr.(string) != "" {
+		if _, cronErr := cron.ParseStandard(userCronExpr.(string)); cronErr != nil {
+			err = gerror.Newf("userLimitCronCycle 无效: %v", cronErr)
+			return
+		}
+	}
+	if policy, ok := editInfo["rolloverPolicy"]; ok {
+		if err = ValidateRollover(policy.(string)); err != nil {
+			return
+		}
+	}
 
 	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) (err error) {
 		var quotaPoolInfo entity.QuotapoolQuotaPool
 		if err = dao.QuotapoolQuotaPool.Ctx(ctx).Where("quota_pool_name = ?", quotaPoolName).LockUpdate().Scan(&quotaPoolInfo); err != nil {
 			return gerror.Wrap(err, "查询配额池信息失败")
 		}
-		// 将 editInfo 中的字段更新到 quotaPoolInfo
+		remainingBefore, extraBefore := quotaPoolInfo.RemainingQuota, quotaPoolInfo.ExtraQuota
+		// 将 editInfo 中的字段更新到 quotaPoolInfo，所属域创建后不能修改
+		domain := quotaPoolInfo.Domain
 		if err = gconv.Struct(editInfo, &quotaPoolInfo); err != nil {
 			return gerror.Wrap(err, "更新配额池信息失败")
 		}
+		quotaPoolInfo.Domain = domain
 		// 更新配额池信息
 		if _, err := dao.QuotapoolQuotaPool.Ctx(ctx).Where("quota_pool_name = ?", quotaPoolInfo.QuotaPoolName).Data(quotaPoolInfo).Update(); err != nil {
 			return gerror.Wrap(err, "修改配额池失败")
 		}
+		// 余额有变化时记账，加油包增加视为充值，其余视为手动调整
+		if !remainingBefore.Equal(quotaPoolInfo.RemainingQuota) || !extraBefore.Equal(quotaPoolInfo.ExtraQuota) {
+			reason := LedgerManualAdjust
+			if quotaPoolInfo.ExtraQuota.GreaterThan(extraBefore) && remainingBefore.Equal(quotaPoolInfo.RemainingQuota) {
+				reason = LedgerTopUp
+			}
+			if err = WriteLedger(ctx, &LedgerEntry{
+				QuotaPoolName:   quotaPoolInfo.QuotaPoolName,
+				Reason:          reason,
+				RemainingBefore: remainingBefore,
+				RemainingAfter:  quotaPoolInfo.RemainingQuota,
+				ExtraBefore:     extraBefore,
+				ExtraAfter:      quotaPoolInfo.ExtraQuota,
+			}); err != nil {
+				return err
+			}
+			if err = AdjustExtraGrants(ctx, quotaPoolInfo.QuotaPoolName, extraBefore, quotaPoolInfo.ExtraQuota); err != nil {
+				return err
+			}
+		}
 
 		// 对比 Casbin 规则，并作更改
 		// 获取老的配额池映射规则
 		e := casbin.GetEnforcer()
-		userUpns, err := e.GetUsersForRole(quotaPoolInfo.QuotaPoolName)
+		userUpns, err := e.GetUsersForRole(quotaPoolInfo.QuotaPoolName, quotaPoolInfo.Domain)
 		if err != nil {
 			return gerror.Wrap(err, "查询配额池用户组规则失败")
 		}
@@ -84,11 +119,11 @@ func Edit(ctx context.Context, editInfo g.Map) (err error) {
 		for _, upn := range filterRes.UserUpns {
 			if _, ok := oldUpnsMap[upn]; !ok {
 				// 老的没有，新的有，要添加
-				policiesToAdd = append(policiesToAdd, []string{upn, quotaPoolInfo.QuotaPoolName})
+				policiesToAdd = append(policiesToAdd, []string{upn, quotaPoolInfo.QuotaPoolName, quotaPoolInfo.Domain})
 			}
 		}
 		if len(policiesToAdd) != 0 {
-			if _, addErr := e.AddGroupingPolicies(policiesToAdd); addErr != nil {
+			if _, addErr := casbin.AddGroupingPolicies(ctx, "quotaPool.Edit", policiesToAdd, false); addErr != nil {
 				return gerror.Wrapf(addErr, "添加配额池用户组继承关系失败: %v", policiesToAdd)
 			}
 		}
@@ -97,11 +132,11 @@ func Edit(ctx context.Context, editInfo g.Map) (err error) {
 		for _, upn := range userUpns {
 			if _, ok := newUpnsMap[upn]; !ok {
 				// 老的有，新的没有，要删除
-				policiesToDelete = append(policiesToDelete, []string{upn, quotaPoolInfo.QuotaPoolName})
+				policiesToDelete = append(policiesToDelete, []string{upn, quotaPoolInfo.QuotaPoolName, quotaPoolInfo.Domain})
 			}
 		}
 		if len(policiesToDelete) != 0 {
-			if _, delErr := e.RemoveGroupingPolicies(policiesToDelete); delErr != nil {
+			if _, delErr := casbin.RemoveGroupingPolicies(ctx, "quotaPool.Edit", policiesToDelete); delErr != nil {
 				return gerror.Wrapf(delErr, "删除配额池用户组继承关系失败: %v", policiesToDelete)
 			}
 		}
@@ -0,0 +1,347 @@
+package quotaPool
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// 加油包的状态
+const (
+	GrantActive   = "active"
+	GrantDepleted = "depleted"
+	GrantExpired  = "expired"
+)
+
+// 加油包的来源
+const (
+	GrantOriginTopUp    = "top_up"
+	GrantOriginTransfer = "transfer"
+	GrantOriginEdit     = "edit"
+)
+
+// 加油包相关的账本变动原因
+const (
+	LedgerTransferIn  = "transfer_in"
+	LedgerTransferOut = "transfer_out"
+	LedgerGrantExpire = "grant_expire"
+)
+
+// GrantPortion 从某个加油包中扣出的一部分额度
+type GrantPortion struct {
+	ExpiresAt *gtime.Time
+	Amount    decimal.Decimal
+}
+
+// lockQuotaPool 查询并锁定配额池，需要在事务中调用。
+func lockQuotaPool(ctx context.Context, quotaPoolName string) (quotaPool *entity.QuotapoolQuotaPool, err error) {
+	if err = dao.QuotapoolQuotaPool.Ctx(ctx).
+		Where("quota_pool_name = ?", quotaPoolName).
+		LockUpdate().
+		Scan(&quotaPool); err != nil {
+		return nil, gerror.Wrap(err, "查询配额池信息失败")
+	}
+	if quotaPool == nil {
+		return nil, gerror.Newf("该配额池不存在，请重新检查：%v", quotaPoolName)
+	}
+	return
+}
+
+// addGrant 新增一条加油包记录，不修改配额池的 extra_quota。
+func addGrant(ctx context.Context, quotaPoolName string, amount decimal.Decimal, expiresAt *gtime.Time, origin string, remark *gjson.Json) (grantId int64, err error) {
+	grantId, err = dao.QuotapoolExtraGrant.Ctx(ctx).Data(g.Map{
+		"quota_pool_name": quotaPoolName,
+		"amount":          amount,
+		"remaining":       amount,
+		"expires_at":      expiresAt,
+		"status":          GrantActive,
+		"origin":          origin,
+		"actor":           LedgerActor(ctx),
+		"remark":          remark,
+	}).InsertAndGetId()
+	if err != nil {
+		return 0, gerror.Wrapf(err, "新增配额池 %v 的加油包失败", quotaPoolName)
+	}
+	return
+}
+
+// ConsumeExtraGrants 按过期时间从早到晚扣减配额池的加油包，永不过期的加油包最后扣减。
+//
+// 只修改加油包记录，配额池的 extra_quota 由调用方更新。需要在已经锁定配额池的事务中调用。
+// 加油包总额不足时只扣到 0，不足部分视为来自没有加油包记录的历史余额。
+func ConsumeExtraGrants(ctx context.Context, quotaPoolName string, amount decimal.Decimal) (portions []*GrantPortion, err error) {
+	if !amount.IsPositive() {
+		return nil, nil
+	}
+	var grants []*entity.QuotapoolExtraGrant
+	if err = dao.QuotapoolExtraGrant.Ctx(ctx).
+		Where("quota_pool_name = ?", quotaPoolName).
+		Where("status = ?", GrantActive).
+		Where("expires_at IS NULL OR expires_at > ?", gtime.Now()).
+		Order("expires_at ASC NULLS LAST, id ASC").
+		LockUpdate().
+		Scan(&grants); err != nil {
+		return nil, gerror.Wrap(err, "查询配额池的有效加油包失败")
+	}
+	for _, grant := range grants {
+		if !amount.IsPositive() {
+			break
+		}
+		deduction := decimal.Min(grant.Remaining, amount)
+		remaining := grant.Remaining.Sub(deduction)
+		data := g.Map{
+			"remaining":  remaining,
+			"updated_at": gtime.Now(),
+		}
+		if !remaining.IsPositive() {
+			data["status"] = GrantDepleted
+		}
+		if _, err = dao.QuotapoolExtraGrant.Ctx(ctx).WherePri(grant.Id).Data(data).Update(); err != nil {
+			return nil, gerror.Wrapf(err, "扣减加油包 %v 失败", grant.Id)
+		}
+		portions = append(portions, &GrantPortion{ExpiresAt: grant.ExpiresAt, Amount: deduction})
+		amount = amount.Sub(deduction)
+	}
+	return
+}
+
+// ExpireExtraGrants 将配额池中已经到期的加油包标记为过期，并从 extra_quota 中扣除，返回扣除的额度。
+//
+// 会先锁定配额池再锁定加油包，和扣费流程的加锁顺序一致。
+func ExpireExtraGrants(ctx context.Context, quotaPoolName string) (expired decimal.Decimal, err error) {
+	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		quotaPool, err := lockQuotaPool(ctx, quotaPoolName)
+		if err != nil {
+			return err
+		}
+		var grants []*entity.QuotapoolExtraGrant
+		if err = dao.QuotapoolExtraGrant.Ctx(ctx).
+			Where("quota_pool_name = ?", quotaPoolName).
+			Where("status = ?", GrantActive).
+			Where("expires_at <= ?", gtime.Now()).
+			LockUpdate().
+			Scan(&grants); err != nil {
+			return gerror.Wrap(err, "查询到期的加油包失败")
+		}
+		if len(grants) == 0 {
+			return nil
+		}
+		grantIds := make([]int64, 0, len(grants))
+		for _, grant := range grants {
+			grantIds = append(grantIds, grant.Id)
+			expired = expired.Add(grant.Remaining)
+		}
+		if _, err = dao.QuotapoolExtraGrant.Ctx(ctx).
+			WhereIn("id", grantIds).
+			Data(g.Map{
+				"status":     GrantExpired,
+				"updated_at": gtime.Now(),
+			}).
+			Update(); err != nil {
+			return gerror.Wrap(err, "标记加油包过期失败")
+		}
+
+		// extra_quota 不会被扣成负数
+		expired = decimal.Min(expired, decimal.Max(quotaPool.ExtraQuota, decimal.Zero))
+		if expired.IsZero() {
+			return nil
+		}
+		extraAfter := quotaPool.ExtraQuota.Sub(expired)
+		if _, err = dao.QuotapoolQuotaPool.Ctx(ctx).
+			Where("quota_pool_name = ?", quotaPoolName).
+			Data(g.Map{"extra_quota": extraAfter}).
+			Update(); err != nil {
+			return gerror.Wrap(err, "扣除过期加油包额度失败")
+		}
+		return WriteLedger(ctx, &LedgerEntry{
+			QuotaPoolName:   quotaPoolName,
+			Reason:          LedgerGrantExpire,
+			Actor:           LedgerActorSystem,
+			RemainingBefore: quotaPool.RemainingQuota,
+			RemainingAfter:  quotaPool.RemainingQuota,
+			ExtraBefore:     quotaPool.ExtraQuota,
+			ExtraAfter:      extraAfter,
+			Remark:          gjson.New(g.Map{"grantIds": grantIds}),
+		})
+	})
+	if err != nil {
+		return decimal.Zero, gerror.Wrapf(err, "过期配额池 %v 的加油包事务失败", quotaPoolName)
+	}
+	return
+}
+
+// SweepExpiredExtraGrants 处理所有配额池中已经到期的加油包，返回处理的配额池数量。
+// 每个配额池单独一个事务，某个配额池失败不影响其他配额池。
+func SweepExpiredExtraGrants(ctx context.Context) (int, error) {
+	poolNames, err := dao.QuotapoolExtraGrant.Ctx(ctx).
+		Fields("DISTINCT quota_pool_name").
+		Where("status = ?", GrantActive).
+		Where("expires_at <= ?", gtime.Now()).
+		Array()
+	if err != nil {
+		return 0, gerror.Wrap(err, "查询存在到期加油包的配额池失败")
+	}
+	swept := 0
+	for _, poolName := range poolNames {
+		if _, err := ExpireExtraGrants(ctx, poolName.String()); err != nil {
+			g.Log().Errorf(ctx, "过期配额池 %v 的加油包失败: %v", poolName, err)
+			continue
+		}
+		swept++
+	}
+	return swept, nil
+}
+
+// TopUp 给配额池充值一笔加油包。expiresAt 为空时永不过期。
+func TopUp(ctx context.Context, quotaPoolName string, amount decimal.Decimal, expiresAt *gtime.Time, remark *gjson.Json) (grantId int64, err error) {
+	if !amount.IsPositive() {
+		return 0, gerror.New("加油包额度必须大于 0")
+	}
+	if expiresAt != nil && !expiresAt.After(gtime.Now()) {
+		return 0, gerror.New("加油包的过期时间必须晚于当前时间")
+	}
+	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		quotaPool, err := lockQuotaPool(ctx, quotaPoolName)
+		if err != nil {
+			return err
+		}
+		if grantId, err = addGrant(ctx, quotaPoolName, amount, expiresAt, GrantOriginTopUp, remark); err != nil {
+			return err
+		}
+		extraAfter := quotaPool.ExtraQuota.Add(amount)
+		if _, err = dao.QuotapoolQuotaPool.Ctx(ctx).
+			Where("quota_pool_name = ?", quotaPoolName).
+			Data(g.Map{"extra_quota": extraAfter}).
+			Update(); err != nil {
+			return gerror.Wrap(err, "更新配额池加油包额度失败")
+		}
+		return WriteLedger(ctx, &LedgerEntry{
+			QuotaPoolName:   quotaPoolName,
+			Reason:          LedgerTopUp,
+			RemainingBefore: quotaPool.RemainingQuota,
+			RemainingAfter:  quotaPool.RemainingQuota,
+			ExtraBefore:     quotaPool.ExtraQuota,
+			ExtraAfter:      extraAfter,
+			Remark:          gjson.New(g.Map{"grantId": grantId}),
+		})
+	})
+	if err != nil {
+		return 0, gerror.Wrapf(err, "配额池 %v 充值加油包事务失败", quotaPoolName)
+	}
+	return
+}
+
+// Transfer 将一个配额池的加油包额度转给另一个配额池。
+//
+// 两个配额池按名称顺序加锁，避免并发互转时死锁。转出的额度按过期时间从早到晚扣减，
+// 转入方按原来的过期时间生成对应的加油包。
+func Transfer(ctx context.Context, from, to string, amount decimal.Decimal, remark *gjson.Json) error {
+	if from == to {
+		return gerror.New("转出和转入的配额池不能相同")
+	}
+	if !amount.IsPositive() {
+		return gerror.New("转移额度必须大于 0")
+	}
+	err := dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		names := []string{from, to}
+		sort.Strings(names)
+		pools := make(map[string]*entity.QuotapoolQuotaPool, 2)
+		for _, name := range names {
+			// 先把到期的加油包扣掉，再按最新余额判断
+			if _, err := ExpireExtraGrants(ctx, name); err != nil {
+				return err
+			}
+			quotaPool, err := lockQuotaPool(ctx, name)
+			if err != nil {
+				return err
+			}
+			pools[name] = quotaPool
+		}
+		fromPool, toPool := pools[from], pools[to]
+		if fromPool.ExtraQuota.LessThan(amount) {
+			return gerror.Newf("配额池 %v 的加油包余额不足。加油包余额：%v，转移额度：%v", from, fromPool.ExtraQuota, amount)
+		}
+
+		portions, err := ConsumeExtraGrants(ctx, from, amount)
+		if err != nil {
+			return err
+		}
+		// 没有加油包记录的历史余额，转入后永不过期
+		tracked := decimal.Zero
+		for _, portion := range portions {
+			tracked = tracked.Add(portion.Amount)
+		}
+		if untracked := amount.Sub(tracked); untracked.IsPositive() {
+			portions = append(portions, &GrantPortion{Amount: untracked})
+		}
+		for _, portion := range portions {
+			if _, err = addGrant(ctx, to, portion.Amount, portion.ExpiresAt, GrantOriginTransfer, gjson.New(g.Map{"from": from})); err != nil {
+				return err
+			}
+		}
+
+		fromExtraAfter := fromPool.ExtraQuota.Sub(amount)
+		toExtraAfter := toPool.ExtraQuota.Add(amount)
+		if _, err = dao.QuotapoolQuotaPool.Ctx(ctx).
+			Where("quota_pool_name = ?", from).
+			Data(g.Map{"extra_quota": fromExtraAfter}).
+			Update(); err != nil {
+			return gerror.Wrap(err, "更新转出配额池的加油包额度失败")
+		}
+		if _, err = dao.QuotapoolQuotaPool.Ctx(ctx).
+			Where("quota_pool_name = ?", to).
+			Data(g.Map{"extra_quota": toExtraAfter}).
+			Update(); err != nil {
+			return gerror.Wrap(err, "更新转入配额池的加油包额度失败")
+		}
+
+		if err = WriteLedger(ctx, &LedgerEntry{
+			QuotaPoolName:   from,
+			Reason:          LedgerTransferOut,
+			RemainingBefore: fromPool.RemainingQuota,
+			RemainingAfter:  fromPool.RemainingQuota,
+			ExtraBefore:     fromPool.ExtraQuota,
+			ExtraAfter:      fromExtraAfter,
+			Remark:          gjson.New(g.Map{"to": to, "remark": remark}),
+		}); err != nil {
+			return err
+		}
+		return WriteLedger(ctx, &LedgerEntry{
+			QuotaPoolName:   to,
+			Reason:          LedgerTransferIn,
+			RemainingBefore: toPool.RemainingQuota,
+			RemainingAfter:  toPool.RemainingQuota,
+			ExtraBefore:     toPool.ExtraQuota,
+			ExtraAfter:      toExtraAfter,
+			Remark:          gjson.New(g.Map{"from": from, "remark": remark}),
+		})
+	})
+	if err != nil {
+		return gerror.Wrapf(err, "配额池 %v 向 %v 转移加油包事务失败", from, to)
+	}
+	return nil
+}
+
+// AdjustExtraGrants 在直接修改 extra_quota 后同步加油包记录：增加的部分记为一条永不过期的加油包，
+// 减少的部分按过期时间从早到晚扣减。需要在已经锁定配额池的事务中调用。
+func AdjustExtraGrants(ctx context.Context, quotaPoolName string, extraBefore, extraAfter decimal.Decimal) error {
+	delta := extraAfter.Sub(extraBefore)
+	switch {
+	case delta.IsPositive():
+		_, err := addGrant(ctx, quotaPoolName, delta, nil, GrantOriginEdit, nil)
+		return err
+	case delta.IsNegative():
+		_, err := ConsumeExtraGrants(ctx, quotaPoolName, delta.Neg())
+		return err
+	}
+	return nil
+}
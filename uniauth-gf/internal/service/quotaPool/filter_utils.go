@@ -15,17 +15,22 @@ import (
 
 // 字段白名单，防止用户查询任意字段
 var allowedFields = g.MapStrStr{
-	"quotaPoolName":  dao.QuotapoolQuotaPool.Columns().QuotaPoolName,
-	"cronCycle":      dao.QuotapoolQuotaPool.Columns().CronCycle,
-	"regularQuota":   dao.QuotapoolQuotaPool.Columns().RegularQuota,
-	"remainingQuota": dao.QuotapoolQuotaPool.Columns().RemainingQuota,
-	"lastResetAt":    dao.QuotapoolQuotaPool.Columns().LastResetAt,
-	"extraQuota":     dao.QuotapoolQuotaPool.Columns().ExtraQuota,
-	"personal":       dao.QuotapoolQuotaPool.Columns().Personal,
-	"disabled":       dao.QuotapoolQuotaPool.Columns().Disabled,
-	"userinfosRules": dao.QuotapoolQuotaPool.Columns().UserinfosRules,
-	"createdAt":      dao.QuotapoolQuotaPool.Columns().CreatedAt,
-	"updatedAt":      dao.QuotapoolQuotaPool.Columns().UpdatedAt,
+	"quotaPoolName":      dao.QuotapoolQuotaPool.Columns().QuotaPoolName,
+	"cronCycle":          dao.QuotapoolQuotaPool.Columns().CronCycle,
+	"regularQuota":       dao.QuotapoolQuotaPool.Columns().RegularQuota,
+	"remainingQuota":     dao.QuotapoolQuotaPool.Columns().RemainingQuota,
+	"lastResetAt":        dao.QuotapoolQuotaPool.Columns().LastResetAt,
+	"extraQuota":         dao.QuotapoolQuotaPool.Columns().ExtraQuota,
+	"personal":           dao.QuotapoolQuotaPool.Columns().Personal,
+	"disabled":           dao.QuotapoolQuotaPool.Columns().Disabled,
+	"userinfosRules":     dao.QuotapoolQuotaPool.Columns().UserinfosRules,
+	"userQuotaLimit":     dao.QuotapoolQuotaPool.Columns().UserQuotaLimit,
+	"userLimitCronCycle": dao.QuotapoolQuotaPool.Columns().UserLimitCronCycle,
+	"rolloverPolicy":     dao.QuotapoolQuotaPool.Columns().RolloverPolicy,
+	"rolloverCapPercent": dao.QuotapoolQuotaPool.Columns().RolloverCapPercent,
+	"rolloverCapAmount":  dao.QuotapoolQuotaPool.Columns().RolloverCapAmount,
+	"createdAt":          dao.QuotapoolQuotaPool.Columns().CreatedAt,
+	"updatedAt":          dao.QuotapoolQuotaPool.Columns().UpdatedAt,
 }
 
 // 支持排序的字段
@@ -38,6 +43,8 @@ var sortableFields = g.MapStrBool{
 	"extraQuota":     true,
 	"personal":       true,
 	"disabled":       true,
+	"userQuotaLimit": true,
+	"rolloverPolicy": true,
 	"createdAt":      true,
 	"updatedAt":      true,
 }
@@ -12,6 +12,7 @@ import (
 )
 
 // GetAllEnabledQuotaPoolsForUser 获取用户所有启用了的配额池，并检查用户是否拥有任何（包括已禁用的）个人配额池。原理是通过 Casbin 查询用户的配额池，然后查配额池表判断配额池是否被禁用。
+// 用户在各个域中的配额池都会返回，只有继承关系所在的域与配额池所属的域一致时才算拥有该配额池。
 // 参数说明：
 //
 //	ctx: 上下文对象，用于控制超时、取消信号等。
@@ -25,10 +26,32 @@ import (
 //	err: 错误信息，若无错误则为 nil。
 func GetAllEnabledQuotaPoolsForUser(ctx context.Context, upn string) (quotaPools []string, personalMap g.MapStrBool, havePersonal bool, err error) {
 	e := casbin.GetEnforcer()
-	roles, err := e.GetRolesForUser(upn)
+	groupings, err := e.GetFilteredGroupingPolicy(0, upn)
 	if err != nil {
 		return nil, nil, false, gerror.Wrap(err, "获取用户所有角色时发生内部错误")
 	}
+	// 按域查询角色，角色管理器会跳过有效期外的继承关系
+	roles := make([]string, 0, len(groupings))
+	roleDomains := make(map[string]map[string]bool, len(groupings))
+	checked := map[string]bool{}
+	for _, grouping := range groupings {
+		domain := casbin.RuleDomain(casbin.PTypeGrouping, grouping)
+		if checked[domain] {
+			continue
+		}
+		checked[domain] = true
+		domainRoles, err := e.GetRolesForUser(upn, domain)
+		if err != nil {
+			return nil, nil, false, gerror.Wrap(err, "获取用户所有角色时发生内部错误")
+		}
+		for _, role := range domainRoles {
+			if roleDomains[role] == nil {
+				roles = append(roles, role)
+				roleDomains[role] = map[string]bool{}
+			}
+			roleDomains[role][domain] = true
+		}
+	}
 	if len(roles) == 0 {
 		return []string{}, g.MapStrBool{}, false, nil
 	}
@@ -47,6 +70,9 @@ func GetAllEnabledQuotaPoolsForUser(ctx context.Context, upn string) (quotaPools
 	personalMap = make(map[string]bool, len(roles))
 	for _, role := range roles {
 		qp, ok := foundPools[role]
+		if ok && !roleDomains[role][qp.Domain] {
+			continue
+		}
 		if !ok {
 			g.Log().Warningf(ctx, "%v 拥有这个配额池，但是没有找到这个配额池的记录：%v", upn, role)
 			continue
@@ -0,0 +1,77 @@
+package quotaPool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/operator"
+	"uniauth-gf/internal/service/poolAlert"
+)
+
+// 配额池账本的变动原因
+const (
+	LedgerCharge       = "charge"        // 计费扣费
+	LedgerCronReset    = "cron_reset"    // 按刷新周期重置
+	LedgerManualReset  = "manual_reset"  // 管理员手动重置
+	LedgerTopUp        = "top_up"        // 加油包充值
+	LedgerManualAdjust = "manual_adjust" // 管理员手动调整（如减少加油包）
+	LedgerRuleSync     = "rule_sync"     // 自动配额池规则同步
+)
+
+// LedgerActorSystem 后台流程（定时任务、懒刷新等）写入账本时使用的操作者
+const LedgerActorSystem = operator.ActorSystem
+
+// LedgerEntry 配额池的一次余额变动
+type LedgerEntry struct {
+	QuotaPoolName   string
+	Reason          string
+	Actor           string // 为空时根据 ctx 推断
+	RemainingBefore decimal.Decimal
+	RemainingAfter  decimal.Decimal
+	ExtraBefore     decimal.Decimal
+	ExtraAfter      decimal.Decimal
+	BillingRecordId int64 // 仅扣费时有值
+	Remark          *gjson.Json
+}
+
+// WriteLedger 向配额池账本追加一条余额变动记录。
+//
+// 账本只追加不修改，需要和余额更新放在同一个事务中调用，保证账本和余额一致。
+// 写入账本的同时会评估配额池的低余额告警规则。
+func WriteLedger(ctx context.Context, entry *LedgerEntry) error {
+	actor := entry.Actor
+	if actor == "" {
+		actor = LedgerActor(ctx)
+	}
+	data := g.Map{
+		"quota_pool_name":  entry.QuotaPoolName,
+		"reason":           entry.Reason,
+		"actor":            actor,
+		"remaining_before": entry.RemainingBefore,
+		"remaining_after":  entry.RemainingAfter,
+		"extra_before":     entry.ExtraBefore,
+		"extra_after":      entry.ExtraAfter,
+		"delta":            entry.RemainingAfter.Add(entry.ExtraAfter).Sub(entry.RemainingBefore).Sub(entry.ExtraBefore),
+		"remark":           entry.Remark,
+	}
+	if entry.BillingRecordId != 0 {
+		data["billing_record_id"] = entry.BillingRecordId
+	}
+	if _, err := dao.QuotapoolLedger.Ctx(ctx).Data(data).Insert(); err != nil {
+		return gerror.Wrapf(err, "写入配额池 %v 的账本失败", entry.QuotaPoolName)
+	}
+	return poolAlert.Evaluate(ctx, entry.QuotaPoolName,
+		entry.RemainingBefore.Add(entry.ExtraBefore),
+		entry.RemainingAfter.Add(entry.ExtraAfter),
+	)
+}
+
+// LedgerActor 推断当前操作者，与权限审计日志的操作者一致，见 operator.Actor。
+func LedgerActor(ctx context.Context) string {
+	return operator.Actor(ctx)
+}
@@ -0,0 +1,199 @@
+package quotaPool
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// 配额预留的状态
+const (
+	ReservationHeld      = "held"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// ReserveInfo 新建配额预留所需的信息
+type ReserveInfo struct {
+	QuotaPoolName string
+	Upn           string
+	Svc           string
+	Product       string
+	Amount        decimal.Decimal
+	Ttl           int // 秒
+}
+
+// HeldAmount 返回配额池当前所有未过期预留的冻结总额。
+func HeldAmount(ctx context.Context, quotaPoolName string) (decimal.Decimal, error) {
+	heldRaw, err := dao.QuotapoolReservation.Ctx(ctx).
+		Fields("COALESCE(SUM(amount), 0)").
+		Where("quota_pool_name = ?", quotaPoolName).
+		Where("status = ?", ReservationHeld).
+		Where("expires_at > ?", gtime.Now()).
+		Value()
+	if err != nil {
+		return decimal.Zero, gerror.Wrapf(err, "查询配额池 %v 的冻结额度失败", quotaPoolName)
+	}
+	held, err := decimal.NewFromString(heldRaw.String())
+	if err != nil {
+		return decimal.Zero, gerror.Wrapf(err, "解析配额池 %v 的冻结额度失败", quotaPoolName)
+	}
+	return held, nil
+}
+
+// Reserve 在配额池上冻结一笔预估额度，返回新建的预留记录。
+//
+// 可用余额 = 剩余配额 + 加油包 - 未过期预留的冻结总额。可用余额不足，或超出用户在该配额池的个人限额时返回错误，不会冻结。
+func Reserve(ctx context.Context, info *ReserveInfo) (reservation *entity.QuotapoolReservation, err error) {
+	if !info.Amount.IsPositive() {
+		return nil, gerror.New("预留金额必须大于 0")
+	}
+	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		// 懒刷新并锁定配额池，同一配额池的预留串行执行
+		balance, err := ResetBalance(ctx, info.QuotaPoolName, false)
+		if err != nil {
+			return gerror.Wrap(err, "刷新配额池余额失败")
+		}
+		disabled, err := dao.QuotapoolQuotaPool.Ctx(ctx).
+			Fields("disabled").
+			Where("quota_pool_name = ?", info.QuotaPoolName).
+			Value()
+		if err != nil {
+			return gerror.Wrap(err, "查询配额池状态失败")
+		}
+		if disabled.Bool() {
+			return gerror.New("这个配额池被禁用了，不能使用")
+		}
+		held, err := HeldAmount(ctx, info.QuotaPoolName)
+		if err != nil {
+			return err
+		}
+		if available := balance.Sub(held); available.LessThan(info.Amount) {
+			return gerror.Newf("配额池可用余额不足。可用余额：%v，预留金额：%v", available, info.Amount)
+		}
+		// 共享配额池内的个人限额
+		_, allowance, err := CheckUserAllowance(ctx, info.QuotaPoolName, info.Upn)
+		if err != nil {
+			return gerror.Wrap(err, "检查个人限额失败")
+		}
+		if allowance.Limited && allowance.Remaining.LessThan(info.Amount) {
+			return gerror.Newf("已超出该配额池的个人限额。本周期剩余：%v，预留金额：%v", allowance.Remaining, info.Amount)
+		}
+
+		reservation = &entity.QuotapoolReservation{
+			ReservationId: uuid.New().String(),
+			QuotaPoolName: info.QuotaPoolName,
+			Upn:           info.Upn,
+			Svc:           info.Svc,
+			Product:       info.Product,
+			Amount:        info.Amount,
+			Status:        ReservationHeld,
+			ExpiresAt:     gtime.Now().Add(time.Duration(info.Ttl) * time.Second),
+		}
+		if _, err = dao.QuotapoolReservation.Ctx(ctx).Data(g.Map{
+			"reservation_id":  reservation.ReservationId,
+			"quota_pool_name": reservation.QuotaPoolName,
+			"upn":             reservation.Upn,
+			"svc":             reservation.Svc,
+			"product":         reservation.Product,
+			"amount":          reservation.Amount,
+			"status":          reservation.Status,
+			"expires_at":      reservation.ExpiresAt,
+		}).Insert(); err != nil {
+			return gerror.Wrap(err, "写入预留记录失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, gerror.Wrapf(err, "配额池 %v 预留额度事务失败", info.QuotaPoolName)
+	}
+	return
+}
+
+// GetReservationForUpdate 查询并锁定预留记录，需要在事务中调用。
+func GetReservationForUpdate(ctx context.Context, reservationId string) (reservation *entity.QuotapoolReservation, err error) {
+	if err = dao.QuotapoolReservation.Ctx(ctx).
+		Where("reservation_id = ?", reservationId).
+		LockUpdate().
+		Scan(&reservation); err != nil {
+		return nil, gerror.Wrap(err, "查询预留记录失败")
+	}
+	if reservation == nil {
+		return nil, gerror.Newf("预留记录不存在，请重新检查：%v", reservationId)
+	}
+	return
+}
+
+// MarkReservationCommitted 将预留标记为已提交，并记录实际费用和计费记录 ID。需要在事务中调用。
+func MarkReservationCommitted(ctx context.Context, reservationId string, actualCost decimal.Decimal, billingRecordId int64) error {
+	if _, err := dao.QuotapoolReservation.Ctx(ctx).
+		Where("reservation_id = ?", reservationId).
+		Data(g.Map{
+			"status":            ReservationCommitted,
+			"actual_cost":       actualCost,
+			"billing_record_id": billingRecordId,
+			"updated_at":        gtime.Now(),
+		}).
+		Update(); err != nil {
+		return gerror.Wrap(err, "更新预留记录为已提交失败")
+	}
+	return nil
+}
+
+// Release 释放一笔尚未提交的预留。已经释放或过期的预留重复释放不会报错。
+func Release(ctx context.Context, reservationId string) error {
+	err := dao.QuotapoolReservation.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		reservation, err := GetReservationForUpdate(ctx, reservationId)
+		if err != nil {
+			return err
+		}
+		switch reservation.Status {
+		case ReservationReleased, ReservationExpired:
+			return nil
+		case ReservationCommitted:
+			return gerror.New("预留已经提交，不能释放")
+		}
+		if _, err = dao.QuotapoolReservation.Ctx(ctx).
+			Where("reservation_id = ?", reservationId).
+			Data(g.Map{
+				"status":     ReservationReleased,
+				"updated_at": gtime.Now(),
+			}).
+			Update(); err != nil {
+			return gerror.Wrap(err, "更新预留记录为已释放失败")
+		}
+		return nil
+	})
+	if err != nil {
+		return gerror.Wrapf(err, "释放预留 %v 事务失败", reservationId)
+	}
+	return nil
+}
+
+// ExpireReservations 将所有超过有效期仍未提交的预留标记为过期，返回本次过期的条数。
+//
+// 过期的预留在计算冻结额度时本来就会被忽略，这里只是把状态落库，便于排查。多个实例同时执行也是安全的。
+func ExpireReservations(ctx context.Context) (int64, error) {
+	result, err := dao.QuotapoolReservation.Ctx(ctx).
+		Where("status = ?", ReservationHeld).
+		Where("expires_at <= ?", gtime.Now()).
+		Data(g.Map{
+			"status":     ReservationExpired,
+			"updated_at": gtime.Now(),
+		}).
+		Update()
+	if err != nil {
+		return 0, gerror.Wrap(err, "过期预留记录失败")
+	}
+	return result.RowsAffected()
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 
 	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/os/gtime"
-	"github.com/robfig/cron/v3"
 	"github.com/shopspring/decimal"
 
 	"uniauth-gf/internal/dao"
@@ -14,9 +14,14 @@ import (
 )
 
 // ResetBalance 重置指定配额池的余额为定期配额，并更新时间。
+// 上一周期的结余或欠费按配额池的结转策略处理，实际应用的策略记录在账本的 remark 中。
 // resetAnyway 为可选参数，默认值为 false。为 true 时强制更新余额。
 func ResetBalance(ctx context.Context, quotaPoolName string, resetAnyway bool) (remainingQuota decimal.Decimal, err error) {
 	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		// 先扣除到期的加油包
+		if _, err := ExpireExtraGrants(ctx, quotaPoolName); err != nil {
+			return err
+		}
 		var quotaPool *entity.QuotapoolQuotaPool
 		if err := dao.QuotapoolQuotaPool.Ctx(ctx).
 			Where("quota_pool_name = ?", quotaPoolName).
@@ -28,14 +33,15 @@ func ResetBalance(ctx context.Context, quotaPoolName string, resetAnyway bool) (
 			return gerror.Newf("该配额池不存在，请重新检查：%v", quotaPoolName)
 		}
 
-		// 懒刷新
-		sched, err := cron.ParseStandard(quotaPool.CronCycle)
+		// 懒刷新，作为定时刷新（ResetDueQuotaPools）的兜底
+		nextReset, err := nextResetAt(quotaPool.CronCycle, quotaPool.LastResetAt)
 		if err != nil {
-			return gerror.Wrapf(err, "配额池 cron_cycle 解析失败。cron_cycle: %v", quotaPool.CronCycle)
+			return err
 		}
-		nextResetAt := sched.Next(quotaPool.LastResetAt.Local().Time)
-		if gtime.Now().Time.After(nextResetAt) || resetAnyway {
-			quotaPool.RemainingQuota = quotaPool.RegularQuota
+		if gtime.Now().Time.After(nextReset) || resetAnyway {
+			remainingBefore := quotaPool.RemainingQuota
+			remainingAfter, rollover := applyRollover(quotaPool)
+			quotaPool.RemainingQuota = remainingAfter
 			quotaPool.LastResetAt = gtime.Now()
 			if _, err := dao.QuotapoolQuotaPool.Ctx(ctx).
 				WherePri(quotaPool.QuotaPoolName).
@@ -43,6 +49,23 @@ func ResetBalance(ctx context.Context, quotaPoolName string, resetAnyway bool) (
 				Update(); err != nil {
 				return gerror.Wrap(err, "更新配额池信息失败")
 			}
+			entry := &LedgerEntry{
+				QuotaPoolName:   quotaPool.QuotaPoolName,
+				Reason:          LedgerCronReset,
+				Actor:           LedgerActorSystem,
+				RemainingBefore: remainingBefore,
+				RemainingAfter:  quotaPool.RemainingQuota,
+				ExtraBefore:     quotaPool.ExtraQuota,
+				ExtraAfter:      quotaPool.ExtraQuota,
+				Remark:          gjson.New(rollover),
+			}
+			if resetAnyway {
+				entry.Reason = LedgerManualReset
+				entry.Actor = ""
+			}
+			if err := WriteLedger(ctx, entry); err != nil {
+				return err
+			}
 		}
 		remainingQuota = quotaPool.RemainingQuota.Add(quotaPool.ExtraQuota)
 		return nil
@@ -0,0 +1,95 @@
+package quotaPool
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/robfig/cron/v3"
+
+	"uniauth-gf/internal/dao"
+)
+
+// 定时刷新使用的 Postgres advisory lock 键，多个实例同时执行时只有一个实例真正扫描
+const resetSchedulerLockKey int64 = 0x7175_6f74_6172_7374
+
+// nextResetAt 根据刷新周期计算上次刷新之后的下一次刷新时间。
+func nextResetAt(cronCycle string, lastResetAt *gtime.Time) (time.Time, error) {
+	sched, err := cron.ParseStandard(cronCycle)
+	if err != nil {
+		return time.Time{}, gerror.Wrapf(err, "配额池 cron_cycle 解析失败。cron_cycle: %v", cronCycle)
+	}
+	var last time.Time
+	if lastResetAt != nil {
+		last = lastResetAt.Local().Time
+	}
+	return sched.Next(last), nil
+}
+
+// ResetDueQuotaPools 主动刷新所有已到刷新时间的配额池，返回本次刷新的配额池数量。
+//
+// 按配额池名称分批扫描，每个到期的配额池在各自的事务中调用 ResetBalance，ResetBalance 在行锁内会再次判断是否到期，
+// 所以和懒刷新、其他实例并发执行都不会重复刷新。扫描期间持有事务级 advisory lock，其他实例拿不到锁时直接跳过本轮。
+func ResetDueQuotaPools(ctx context.Context) (reset int, err error) {
+	batchSize := g.Cfg().MustGetWithEnv(ctx, "quotaPool.resetBatchSize", 200).Int()
+	err = dao.QuotapoolQuotaPool.Transaction(ctx, func(txCtx context.Context, tx gdb.TX) error {
+		locked, err := tx.GetValue("SELECT pg_try_advisory_xact_lock(?)", resetSchedulerLockKey)
+		if err != nil {
+			return gerror.Wrap(err, "获取配额池定时刷新锁失败")
+		}
+		if !locked.Bool() {
+			return nil
+		}
+
+		type poolRow struct {
+			QuotaPoolName string
+			CronCycle     string
+			LastResetAt   *gtime.Time
+		}
+		lastName := ""
+		for {
+			var rows []poolRow
+			// 扫描不在持锁事务中进行，避免长时间持有读快照
+			if err = dao.QuotapoolQuotaPool.Ctx(ctx).
+				Fields("quota_pool_name, cron_cycle, last_reset_at").
+				WhereGT("quota_pool_name", lastName).
+				OrderAsc("quota_pool_name").
+				Limit(batchSize).
+				Scan(&rows); err != nil {
+				return gerror.Wrap(err, "查询配额池列表失败")
+			}
+			if len(rows) == 0 {
+				return nil
+			}
+			lastName = rows[len(rows)-1].QuotaPoolName
+
+			now := time.Now()
+			for _, row := range rows {
+				next, err := nextResetAt(row.CronCycle, row.LastResetAt)
+				if err != nil {
+					g.Log().Warningf(ctx, "跳过配额池 %v 的定时刷新: %v", row.QuotaPoolName, err)
+					continue
+				}
+				if !now.After(next) {
+					continue
+				}
+				// 使用外层 ctx，每个配额池单独提交，不进入持锁的事务
+				if _, err = ResetBalance(ctx, row.QuotaPoolName, false); err != nil {
+					g.Log().Errorf(ctx, "定时刷新配额池 %v 失败: %v", row.QuotaPoolName, err)
+					continue
+				}
+				reset++
+			}
+			if len(rows) < batchSize {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return reset, gerror.Wrap(err, "定时刷新配额池失败")
+	}
+	return
+}
@@ -0,0 +1,56 @@
+package quotaPool
+
+import (
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/model/entity"
+)
+
+// 刷新时对上一周期剩余配额的结转策略
+const (
+	RolloverDiscard   = "discard"    // 剩余配额清零，欠费一并免除
+	RolloverCarryOver = "carry_over" // 结余结转到下一周期（可设上限），欠费免除
+	RolloverCarryDebt = "carry_debt" // 欠费从下一周期扣除，结余清零
+)
+
+// RolloverResult 一次刷新实际应用的结转策略
+type RolloverResult struct {
+	Policy  string          `json:"rolloverPolicy"`
+	Carried decimal.Decimal `json:"carried"` // 结转到下一周期的金额，欠费结转时为负数
+	Dropped decimal.Decimal `json:"dropped"` // 因策略或上限被清零的金额，免除的欠费为负数
+}
+
+// ValidateRollover 校验结转策略，为空时视为 discard。
+func ValidateRollover(policy string) error {
+	switch policy {
+	case "", RolloverDiscard, RolloverCarryOver, RolloverCarryDebt:
+		return nil
+	}
+	return gerror.Newf("rolloverPolicy 无效: %v，可选值为 discard、carry_over、carry_debt", policy)
+}
+
+// applyRollover 按配额池的结转策略计算刷新后的剩余配额。
+//
+// carry_over 的上限取百分比上限（相对定期配额）和绝对值上限中较小的一个，二者都小于 0 时不封顶。
+func applyRollover(quotaPool *entity.QuotapoolQuotaPool) (remainingQuota decimal.Decimal, result *RolloverResult) {
+	leftover := quotaPool.RemainingQuota
+	result = &RolloverResult{Policy: quotaPool.RolloverPolicy, Carried: decimal.Zero}
+	if result.Policy == "" {
+		result.Policy = RolloverDiscard
+	}
+	switch {
+	case result.Policy == RolloverCarryOver && leftover.IsPositive():
+		result.Carried = leftover
+		if !quotaPool.RolloverCapPercent.IsNegative() {
+			result.Carried = decimal.Min(result.Carried, quotaPool.RegularQuota.Mul(quotaPool.RolloverCapPercent).Div(decimal.NewFromInt(100)))
+		}
+		if !quotaPool.RolloverCapAmount.IsNegative() {
+			result.Carried = decimal.Min(result.Carried, quotaPool.RolloverCapAmount)
+		}
+	case result.Policy == RolloverCarryDebt && leftover.IsNegative():
+		result.Carried = leftover
+	}
+	result.Dropped = leftover.Sub(result.Carried)
+	return quotaPool.RegularQuota.Add(result.Carried), result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/service/casbin"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
@@ -13,6 +14,8 @@ import (
 //
 // 如果 qpNameList 传递 nil，则刷新所有配额池；如果传递空数组，则不进行任何操作。
 //
+// 刷新前会自动保存一次 Casbin 规则快照，快照失败时不进行刷新。
+//
 // 如果存在失败的情况，会在处理完一轮后返回发生错误的配额池列表，不影响其他配额池的更新。
 func UpdateQuotaPoolsUsersInCasbin(ctx context.Context, qpNameList *[]string) error {
 	if qpNameList == nil {
@@ -25,6 +28,9 @@ func UpdateQuotaPoolsUsersInCasbin(ctx context.Context, qpNameList *[]string) er
 	if len(*qpNameList) == 0 {
 		return nil // 列表为空，无需操作
 	}
+	if _, err := casbin.TakeSnapshot(ctx, casbin.SnapshotAuto, "", "quotaPool.UpdateQuotaPoolsUsersInCasbin"); err != nil {
+		return gerror.Wrap(err, "刷新配额池用户组前保存规则快照失败")
+	}
 
 	failures := []string{}
 	for _, qp := range *qpNameList {
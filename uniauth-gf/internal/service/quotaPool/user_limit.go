@@ -0,0 +1,170 @@
+package quotaPool
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/robfig/cron/v3"
+	"github.com/shopspring/decimal"
+
+	"uniauth-gf/internal/dao"
+	"uniauth-gf/internal/model/entity"
+)
+
+// UserAllowance 用户在共享配额池内当前统计周期的个人限额使用情况
+type UserAllowance struct {
+	Upn         string
+	Limited     bool            // 配额池是否设置了个人限额
+	Limit       decimal.Decimal // 每周期个人限额，未设置时为 -1
+	Used        decimal.Decimal // 本周期已消费
+	Held        decimal.Decimal // 本人进行中的预留冻结额度
+	Remaining   decimal.Decimal // 本周期剩余可用额度，未设置限额时为 -1
+	PeriodStart *gtime.Time     // 本统计周期的开始时间
+}
+
+// 为了找到上一次触发时间，依次尝试这些回溯窗口，窗口内至少包含一次触发即可
+var lookbackWindows = []time.Duration{
+	time.Minute,
+	time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	31 * 24 * time.Hour,
+	366 * 24 * time.Hour,
+}
+
+// prevScheduleTime 返回不晚于 now 的最近一次触发时间。
+//
+// robfig/cron 只提供 Next，这里从小到大尝试回溯窗口，找到第一个包含触发时间的窗口后再向后逼近，
+// 迭代次数与窗口内的触发次数相当。一年内都没有触发时返回 false。
+func prevScheduleTime(sched cron.Schedule, now time.Time) (time.Time, bool) {
+	for _, window := range lookbackWindows {
+		t := sched.Next(now.Add(-window))
+		if t.IsZero() || t.After(now) {
+			continue
+		}
+		for next := sched.Next(t); !next.IsZero() && !next.After(now); next = sched.Next(next) {
+			t = next
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// userLimitPeriodStart 计算配额池个人限额当前统计周期的开始时间。
+// user_limit_cron_cycle 为空时沿用配额池的 cron_cycle。
+func userLimitPeriodStart(quotaPool *entity.QuotapoolQuotaPool) (*gtime.Time, error) {
+	cronCycle := quotaPool.UserLimitCronCycle
+	if cronCycle == "" {
+		cronCycle = quotaPool.CronCycle
+	}
+	sched, err := cron.ParseStandard(cronCycle)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "配额池个人限额统计周期解析失败。cron: %v", cronCycle)
+	}
+	now := time.Now()
+	start, ok := prevScheduleTime(sched, now)
+	if !ok {
+		// 周期长于一年，按一年统计
+		start = now.AddDate(-1, 0, 0)
+	}
+	return gtime.New(start), nil
+}
+
+// GetUserAllowances 查询给定用户在配额池内当前统计周期的个人限额使用情况。
+//
+// 已消费金额来自 billing_cost_records 中以该配额池为来源、创建时间不早于周期开始的记录；
+// 进行中的预留同样会占用个人额度。
+func GetUserAllowances(ctx context.Context, quotaPoolName string, upns []string) (allowances []*UserAllowance, err error) {
+	var quotaPool *entity.QuotapoolQuotaPool
+	if err = dao.QuotapoolQuotaPool.Ctx(ctx).
+		Where("quota_pool_name = ?", quotaPoolName).
+		Scan(&quotaPool); err != nil {
+		return nil, gerror.Wrap(err, "查询配额池信息失败")
+	}
+	if quotaPool == nil {
+		return nil, gerror.Newf("该配额池不存在，请重新检查：%v", quotaPoolName)
+	}
+	if len(upns) == 0 {
+		return []*UserAllowance{}, nil
+	}
+	periodStart, err := userLimitPeriodStart(quotaPool)
+	if err != nil {
+		return nil, err
+	}
+
+	type sumRow struct {
+		Upn   string
+		Total string
+	}
+	var usedRows, heldRows []sumRow
+	if err = dao.BillingCostRecords.Ctx(ctx).
+		Fields("upn, COALESCE(SUM(cost), 0) AS total").
+		Where("source = ?", quotaPoolName).
+		WhereIn("upn", upns).
+		WhereGTE("created_at", periodStart).
+		Group("upn").
+		Scan(&usedRows); err != nil {
+		return nil, gerror.Wrap(err, "统计用户本周期消费失败")
+	}
+	if err = dao.QuotapoolReservation.Ctx(ctx).
+		Fields("upn, COALESCE(SUM(amount), 0) AS total").
+		Where("quota_pool_name = ?", quotaPoolName).
+		Where("status = ?", ReservationHeld).
+		Where("expires_at > ?", gtime.Now()).
+		WhereIn("upn", upns).
+		Group("upn").
+		Scan(&heldRows); err != nil {
+		return nil, gerror.Wrap(err, "统计用户冻结额度失败")
+	}
+	toMap := func(rows []sumRow) (map[string]decimal.Decimal, error) {
+		m := make(map[string]decimal.Decimal, len(rows))
+		for _, row := range rows {
+			v, err := decimal.NewFromString(row.Total)
+			if err != nil {
+				return nil, gerror.Wrapf(err, "解析用户 %v 的统计金额失败", row.Upn)
+			}
+			m[row.Upn] = v
+		}
+		return m, nil
+	}
+	usedMap, err := toMap(usedRows)
+	if err != nil {
+		return nil, err
+	}
+	heldMap, err := toMap(heldRows)
+	if err != nil {
+		return nil, err
+	}
+
+	limited := !quotaPool.UserQuotaLimit.IsNegative()
+	allowances = make([]*UserAllowance, 0, len(upns))
+	for _, upn := range upns {
+		allowance := &UserAllowance{
+			Upn:         upn,
+			Limited:     limited,
+			Limit:       decimal.NewFromInt(-1),
+			Used:        usedMap[upn],
+			Held:        heldMap[upn],
+			Remaining:   decimal.NewFromInt(-1),
+			PeriodStart: periodStart,
+		}
+		if limited {
+			allowance.Limit = quotaPool.UserQuotaLimit
+			allowance.Remaining = quotaPool.UserQuotaLimit.Sub(allowance.Used).Sub(allowance.Held)
+		}
+		allowances = append(allowances, allowance)
+	}
+	return
+}
+
+// CheckUserAllowance 检查用户在配额池内的个人限额是否还有剩余。配额池未设置个人限额时总是通过。
+func CheckUserAllowance(ctx context.Context, quotaPoolName string, upn string) (ok bool, allowance *UserAllowance, err error) {
+	allowances, err := GetUserAllowances(ctx, quotaPoolName, []string{upn})
+	if err != nil {
+		return false, nil, err
+	}
+	allowance = allowances[0]
+	return !allowance.Limited || allowance.Remaining.IsPositive(), allowance, nil
+}
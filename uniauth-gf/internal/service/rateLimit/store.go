@@ -0,0 +1,151 @@
+package rateLimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"uniauth-gf/internal/dao"
+)
+
+// Limit 令牌桶参数
+type Limit struct {
+	Rate  float64 // 每秒补充的令牌数
+	Burst int     // 桶容量
+}
+
+// Store 令牌桶存储。多个实例部署时需要使用共享的存储，限流才能在实例之间生效。
+type Store interface {
+	// Take 从 key 对应的令牌桶中取一个令牌。桶不存在时按满桶处理。
+	// 没有令牌时返回 false，以及大约多久之后会有新的令牌。
+	Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// retryAfter 桶中还有 tokens 个令牌时，攒够一个令牌需要的时间
+func retryAfter(tokens float64, limit Limit) time.Duration {
+	return time.Duration(math.Ceil((1 - tokens) / limit.Rate * float64(time.Second)))
+}
+
+// MemoryStore 进程内的令牌桶，只对单个实例生效
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	full      time.Time // 桶被补满的时间，之后可以丢弃
+}
+
+// NewMemoryStore 创建进程内的令牌桶存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*memoryBucket{}}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 每分钟清理一次已经补满的桶，避免调用方很多时内存一直增长
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, b := range s.buckets {
+			if now.After(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updatedAt).Seconds()*limit.Rate)
+	b.updatedAt = now
+	if b.tokens < 1 {
+		return false, retryAfter(b.tokens, limit), nil
+	}
+	b.tokens--
+	b.full = now.Add(time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second)))
+	return true, 0, nil
+}
+
+// PostgresStore 保存在 rate_limit_bucket 表中的令牌桶，多个实例共享。
+// 每次取令牌是一条 upsert 语句，补充令牌和扣减在数据库中原子完成。
+type PostgresStore struct{}
+
+// takeSql 桶中令牌不足一个时 WHERE 不成立，不更新也不返回行，剩余令牌继续按原来的时间补充
+const takeSql = `INSERT INTO rate_limit_bucket AS b (key, tokens, updated_at) VALUES (?, ?::float8 - 1, NOW())
+ON CONFLICT (key) DO UPDATE SET
+	tokens = LEAST(?::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * ?::float8) - 1,
+	updated_at = NOW()
+WHERE LEAST(?::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * ?::float8) >= 1
+RETURNING tokens`
+
+func (PostgresStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	burst := float64(limit.Burst)
+	res, err := dao.RateLimitBucket.DB().GetAll(ctx, takeSql, key, burst, burst, limit.Rate, burst, limit.Rate)
+	if err != nil {
+		return false, 0, gerror.Wrapf(err, "获取令牌桶 %v 失败", key)
+	}
+	if res.IsEmpty() {
+		return false, retryAfter(0, limit), nil
+	}
+	return true, 0, nil
+}
+
+// PurgeIdleBuckets 删除一天没有使用的令牌桶，返回删除的条数。被删除的桶下次使用时按满桶处理。
+func PurgeIdleBuckets(ctx context.Context) (int64, error) {
+	res, err := dao.RateLimitBucket.Ctx(ctx).WhereLT("updated_at", gtime.Now().AddDate(0, 0, -1)).Delete()
+	if err != nil {
+		return 0, gerror.Wrap(err, "清理令牌桶失败")
+	}
+	return res.RowsAffected()
+}
+
+var (
+	storeMu sync.RWMutex
+	store   Store
+)
+
+// SetStore 替换当前使用的令牌桶存储，传 nil 时下次使用会重新从配置构建
+func SetStore(s Store) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	store = s
+}
+
+// GetStore 返回当前使用的令牌桶存储，第一次调用时根据 rateLimit.store 构建：
+// postgres（默认）使用 rate_limit_bucket 表，多个实例共享；memory 只对单个实例生效。
+func GetStore(ctx context.Context) Store {
+	storeMu.RLock()
+	s := store
+	storeMu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	if store == nil {
+		switch kind := g.Cfg().MustGetWithEnv(ctx, "rateLimit.store", "postgres").String(); kind {
+		case "memory":
+			store = NewMemoryStore()
+		case "postgres":
+			store = PostgresStore{}
+		default:
+			g.Log().Errorf(ctx, "不支持的令牌桶存储 %v，将使用 postgres", kind)
+			store = PostgresStore{}
+		}
+	}
+	return store
+}
@@ -9,5 +9,8 @@ import (
 )
 
 func main() {
+	if err := cmd.Main.AddCommand(&cmd.Rollup); err != nil {
+		panic(err)
+	}
 	cmd.Main.Run(gctx.GetInitCtx())
 }
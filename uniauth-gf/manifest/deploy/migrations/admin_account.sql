@@ -0,0 +1,46 @@
+CREATE TABLE admin_account (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    display_name VARCHAR(255) NOT NULL DEFAULT '',
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_login_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE admin_account IS '管理员账号。管理员在 Casbin 中的 subject 为 admin:<username>，可以调用哪些管理接口由其 Casbin 权限决定';
+COMMENT ON COLUMN admin_account.id IS '自增主键';
+COMMENT ON COLUMN admin_account.username IS '用户名';
+COMMENT ON COLUMN admin_account.password_hash IS 'bcrypt 密码哈希';
+COMMENT ON COLUMN admin_account.display_name IS '显示名称';
+COMMENT ON COLUMN admin_account.disabled IS '是否禁用，禁用后不能登录，已有会话立即失效';
+COMMENT ON COLUMN admin_account.last_login_at IS '最近登录时间';
+COMMENT ON COLUMN admin_account.created_at IS '创建时间';
+COMMENT ON COLUMN admin_account.updated_at IS '更新时间';
+
+CREATE TABLE admin_session (
+    id BIGSERIAL PRIMARY KEY,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    username VARCHAR(255) NOT NULL,
+    provider VARCHAR(32) NOT NULL DEFAULT 'password',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    client_ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_session_username ON admin_session(username);
+CREATE INDEX idx_admin_session_expires_at ON admin_session(expires_at);
+
+COMMENT ON TABLE admin_session IS '管理员登录会话。令牌只返回给客户端一次，数据库中只保存其 SHA-256 哈希';
+COMMENT ON COLUMN admin_session.id IS '自增主键';
+COMMENT ON COLUMN admin_session.token_hash IS '会话令牌的 SHA-256 哈希（十六进制）';
+COMMENT ON COLUMN admin_session.username IS '管理员用户名，单点登录时为 UPN';
+COMMENT ON COLUMN admin_session.provider IS '登录方式：password 账号密码 | oidc 单点登录';
+COMMENT ON COLUMN admin_session.expires_at IS '过期时间';
+COMMENT ON COLUMN admin_session.revoked_at IS '注销时间，为空表示未注销';
+COMMENT ON COLUMN admin_session.client_ip IS '登录时的客户端 IP';
+COMMENT ON COLUMN admin_session.user_agent IS '登录时的 User-Agent';
+COMMENT ON COLUMN admin_session.created_at IS '登录时间';
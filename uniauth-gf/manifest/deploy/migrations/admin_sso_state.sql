@@ -0,0 +1,18 @@
+CREATE TABLE admin_sso_state (
+    state VARCHAR(64) PRIMARY KEY,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    redirect VARCHAR(1024) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_sso_state_expires_at ON admin_sso_state(expires_at);
+
+COMMENT ON TABLE admin_sso_state IS '进行中的单点登录请求。回调时按 state 取出并删除，每个 state 只能使用一次';
+COMMENT ON COLUMN admin_sso_state.state IS 'OIDC state 参数，防止跨站请求伪造';
+COMMENT ON COLUMN admin_sso_state.nonce IS 'OIDC nonce 参数，防止 ID Token 重放';
+COMMENT ON COLUMN admin_sso_state.code_verifier IS 'PKCE code_verifier';
+COMMENT ON COLUMN admin_sso_state.redirect IS '登录完成后跳转的管理后台地址';
+COMMENT ON COLUMN admin_sso_state.expires_at IS '过期时间';
+COMMENT ON COLUMN admin_sso_state.created_at IS '创建时间';
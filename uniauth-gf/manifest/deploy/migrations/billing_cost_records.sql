@@ -8,6 +8,7 @@ CREATE TABLE billing_cost_records (
     plan VARCHAR(255) NOT NULL,
     source VARCHAR(255) NOT NULL,
     remark JSONB,
+    request_id VARCHAR(255) UNIQUE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
@@ -16,6 +17,7 @@ CREATE INDEX idx_billing_cost_records_svc ON billing_cost_records(svc);
 CREATE INDEX idx_billing_cost_records_svc_and_product ON billing_cost_records(svc, product);
 CREATE INDEX idx_billing_cost_records_source ON billing_cost_records(source);
 CREATE INDEX idx_billing_cost_records_created_at ON billing_cost_records(created_at);
+CREATE INDEX idx_billing_cost_records_source_upn_created_at ON billing_cost_records(source, upn, created_at);
 
 COMMENT ON COLUMN billing_cost_records.id IS '自增主键';
 COMMENT ON COLUMN billing_cost_records.upn IS 'UPN';
@@ -26,4 +28,5 @@ COMMENT ON COLUMN billing_cost_records.original_cost IS '原始费用';
 COMMENT ON COLUMN billing_cost_records.plan IS '计费方案';
 COMMENT ON COLUMN billing_cost_records.source IS '来源';
 COMMENT ON COLUMN billing_cost_records.remark IS '备注信息';
+COMMENT ON COLUMN billing_cost_records.request_id IS '幂等键，由调用方提供，重放时不重复扣费';
 COMMENT ON COLUMN billing_cost_records.created_at IS '创建时间';
\ No newline at end of file
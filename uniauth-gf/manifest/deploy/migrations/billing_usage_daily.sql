@@ -0,0 +1,27 @@
+CREATE TABLE billing_usage_daily (
+    date DATE NOT NULL,
+    upn VARCHAR(255) NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    svc VARCHAR(255) NOT NULL,
+    product VARCHAR(255) NOT NULL,
+    cost NUMERIC(25, 10) NOT NULL DEFAULT 0,
+    original_cost NUMERIC(25, 10) NOT NULL DEFAULT 0,
+    count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (date, upn, source, svc, product)
+);
+
+CREATE INDEX idx_billing_usage_daily_svc_date ON billing_usage_daily(svc, date);
+CREATE INDEX idx_billing_usage_daily_upn_date ON billing_usage_daily(upn, date);
+CREATE INDEX idx_billing_usage_daily_source_date ON billing_usage_daily(source, date);
+
+COMMENT ON TABLE billing_usage_daily IS '每日用量汇总：按日期、UPN、来源、服务、产品聚合的计费记录，供统计接口查询';
+COMMENT ON COLUMN billing_usage_daily.date IS '日期';
+COMMENT ON COLUMN billing_usage_daily.upn IS 'UPN';
+COMMENT ON COLUMN billing_usage_daily.source IS '来源';
+COMMENT ON COLUMN billing_usage_daily.svc IS '服务名称';
+COMMENT ON COLUMN billing_usage_daily.product IS '产品名称';
+COMMENT ON COLUMN billing_usage_daily.cost IS '费用合计';
+COMMENT ON COLUMN billing_usage_daily.original_cost IS '原始费用合计';
+COMMENT ON COLUMN billing_usage_daily.count IS '调用次数';
+COMMENT ON COLUMN billing_usage_daily.updated_at IS '更新时间';
@@ -0,0 +1,28 @@
+CREATE TABLE casbin_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(32) NOT NULL,
+    ptype VARCHAR(8) NOT NULL,
+    old_rules JSONB,
+    new_rules JSONB,
+    actor VARCHAR(255) NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    operation VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_casbin_audit_log_created_at ON casbin_audit_log(created_at);
+CREATE INDEX idx_casbin_audit_log_actor_created_at ON casbin_audit_log(actor, created_at);
+CREATE INDEX idx_casbin_audit_log_request_id ON casbin_audit_log(request_id);
+
+COMMENT ON TABLE casbin_audit_log IS 'Casbin 权限变更审计日志，只追加不修改';
+COMMENT ON COLUMN casbin_audit_log.id IS '自增主键';
+COMMENT ON COLUMN casbin_audit_log.action IS '变更类型：add | remove | update';
+COMMENT ON COLUMN casbin_audit_log.ptype IS '规则类型：p | g';
+COMMENT ON COLUMN casbin_audit_log.old_rules IS '变更前的规则，新增时为空';
+COMMENT ON COLUMN casbin_audit_log.new_rules IS '变更后的规则，删除时为空';
+COMMENT ON COLUMN casbin_audit_log.actor IS '操作者：X-Operator 请求头、api:<请求路径> 或 system';
+COMMENT ON COLUMN casbin_audit_log.source IS '来源接口，例如 POST /auth/admin/policies/add，后台流程为 system';
+COMMENT ON COLUMN casbin_audit_log.operation IS '发起变更的业务操作，例如 quotaPool.Edit';
+COMMENT ON COLUMN casbin_audit_log.request_id IS '请求 ID';
+COMMENT ON COLUMN casbin_audit_log.created_at IS '变更时间';
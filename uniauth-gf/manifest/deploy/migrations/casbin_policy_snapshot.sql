@@ -0,0 +1,28 @@
+CREATE TABLE casbin_policy_snapshot (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    kind VARCHAR(32) NOT NULL,
+    operation VARCHAR(255) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL,
+    policies JSONB NOT NULL DEFAULT '[]'::jsonb,
+    groupings JSONB NOT NULL DEFAULT '[]'::jsonb,
+    validities JSONB NOT NULL DEFAULT '[]'::jsonb,
+    policy_count INTEGER NOT NULL DEFAULT 0,
+    grouping_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_casbin_policy_snapshot_kind_created_at ON casbin_policy_snapshot(kind, created_at);
+
+COMMENT ON TABLE casbin_policy_snapshot IS 'Casbin 规则快照，保存某一时刻全部 p 规则和 g 规则，用于对比和回滚';
+COMMENT ON COLUMN casbin_policy_snapshot.id IS '自增主键';
+COMMENT ON COLUMN casbin_policy_snapshot.name IS '快照名称，手动快照时由操作者填写';
+COMMENT ON COLUMN casbin_policy_snapshot.kind IS '快照类型：manual 手动 | auto 批量操作前自动 | rollback 回滚前自动';
+COMMENT ON COLUMN casbin_policy_snapshot.operation IS '触发自动快照的业务操作，例如 quotaPool.UpdateQuotaPoolsUsersInCasbin';
+COMMENT ON COLUMN casbin_policy_snapshot.actor IS '操作者：X-Operator 请求头、api:<请求路径> 或 system';
+COMMENT ON COLUMN casbin_policy_snapshot.policies IS '全部 p 规则';
+COMMENT ON COLUMN casbin_policy_snapshot.groupings IS '全部 g 规则';
+COMMENT ON COLUMN casbin_policy_snapshot.validities IS '设置了有效期的规则及其有效期';
+COMMENT ON COLUMN casbin_policy_snapshot.policy_count IS 'p 规则条数';
+COMMENT ON COLUMN casbin_policy_snapshot.grouping_count IS 'g 规则条数';
+COMMENT ON COLUMN casbin_policy_snapshot.created_at IS '快照时间';
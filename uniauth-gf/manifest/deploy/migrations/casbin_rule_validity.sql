@@ -0,0 +1,20 @@
+CREATE TABLE casbin_rule_validity (
+    id BIGSERIAL PRIMARY KEY,
+    ptype VARCHAR(8) NOT NULL,
+    rule JSONB NOT NULL,
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_casbin_rule_validity_window CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_from < valid_until)
+);
+
+CREATE UNIQUE INDEX uk_casbin_rule_validity_ptype_rule ON casbin_rule_validity(ptype, rule);
+CREATE INDEX idx_casbin_rule_validity_valid_until ON casbin_rule_validity(valid_until);
+
+COMMENT ON TABLE casbin_rule_validity IS 'Casbin 规则的有效期。没有记录的规则永久有效，有效期外的规则不参与鉴权，过期后由定时任务删除';
+COMMENT ON COLUMN casbin_rule_validity.id IS '自增主键';
+COMMENT ON COLUMN casbin_rule_validity.ptype IS '规则类型：p | g';
+COMMENT ON COLUMN casbin_rule_validity.rule IS '规则内容，与 Casbin 中的规则逐字段一致';
+COMMENT ON COLUMN casbin_rule_validity.valid_from IS '生效时间（含），为空表示立即生效';
+COMMENT ON COLUMN casbin_rule_validity.valid_until IS '失效时间（不含），为空表示永不过期';
+COMMENT ON COLUMN casbin_rule_validity.created_at IS '创建时间';
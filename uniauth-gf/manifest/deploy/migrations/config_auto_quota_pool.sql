@@ -9,6 +9,11 @@ CREATE TABLE config_auto_quota_pool (
     default_casbin_rules JSONB,
     upns_cache VARCHAR(255)[],
     priority INTEGER NOT NULL DEFAULT 100,
+    user_quota_limit NUMERIC(25, 10) NOT NULL DEFAULT -1,
+    user_limit_cron_cycle VARCHAR(255) NOT NULL DEFAULT '',
+    rollover_policy VARCHAR(32) NOT NULL DEFAULT 'discard',
+    rollover_cap_percent NUMERIC(25, 10) NOT NULL DEFAULT -1,
+    rollover_cap_amount NUMERIC(25, 10) NOT NULL DEFAULT -1,
     last_evaluated_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
@@ -31,6 +36,11 @@ COMMENT ON COLUMN config_auto_quota_pool.filter_group IS '过滤条件组';
 COMMENT ON COLUMN config_auto_quota_pool.default_casbin_rules IS '默认Casbin规则配置';
 COMMENT ON COLUMN config_auto_quota_pool.upns_cache IS 'UPN缓存列表';
 COMMENT ON COLUMN config_auto_quota_pool.priority IS '优先级，数值越小优先匹配';
+COMMENT ON COLUMN config_auto_quota_pool.user_quota_limit IS '个人配额池中用户每周期的消费上限，小于 0 时不限制';
+COMMENT ON COLUMN config_auto_quota_pool.user_limit_cron_cycle IS '用户消费上限的统计周期，为空时沿用刷新周期';
+COMMENT ON COLUMN config_auto_quota_pool.rollover_policy IS '个人配额池的余额结转策略：discard 清零 | carry_over 结余结转 | carry_debt 欠费结转';
+COMMENT ON COLUMN config_auto_quota_pool.rollover_cap_percent IS '结余结转上限，定期配额的百分比，小于 0 时不限制';
+COMMENT ON COLUMN config_auto_quota_pool.rollover_cap_amount IS '结余结转上限，绝对值，小于 0 时不限制';
 COMMENT ON COLUMN config_auto_quota_pool.last_evaluated_at IS '该规则上次评估时间';
 COMMENT ON COLUMN config_auto_quota_pool.created_at IS '创建时间';
 COMMENT ON COLUMN config_auto_quota_pool.updated_at IS '更新时间';
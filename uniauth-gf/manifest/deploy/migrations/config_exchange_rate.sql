@@ -7,6 +7,9 @@ CREATE TABLE config_exchange_rate (
     PRIMARY KEY (date, f, t)
 );
 
+-- 查询某个日期及之前最近的汇率
+CREATE INDEX idx_config_exchange_rate_f_t_date ON config_exchange_rate(f, t, date DESC);
+
 COMMENT ON COLUMN config_exchange_rate.date IS '汇率日期';
 COMMENT ON COLUMN config_exchange_rate.f IS '本位货币';
 COMMENT ON COLUMN config_exchange_rate.t IS '标的货币';
@@ -0,0 +1,64 @@
+CREATE TABLE quotapool_alert_rule (
+    id BIGSERIAL PRIMARY KEY,
+    quota_pool_name VARCHAR(255) NOT NULL,
+    threshold_type VARCHAR(32) NOT NULL,
+    threshold NUMERIC(25, 10) NOT NULL,
+    webhook_url VARCHAR(1024) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    triggered BOOLEAN NOT NULL DEFAULT FALSE,
+    last_triggered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quotapool_alert_rule_quota_pool_name ON quotapool_alert_rule(quota_pool_name);
+
+COMMENT ON TABLE quotapool_alert_rule IS '配额池余额告警规则：余额低于阈值时向 Webhook 推送告警';
+COMMENT ON COLUMN quotapool_alert_rule.id IS '自增主键';
+COMMENT ON COLUMN quotapool_alert_rule.quota_pool_name IS '配额池名称';
+COMMENT ON COLUMN quotapool_alert_rule.threshold_type IS '阈值类型：absolute 绝对值 | percent 定期配额的百分比';
+COMMENT ON COLUMN quotapool_alert_rule.threshold IS '阈值，可用余额（剩余配额 + 加油包）低于该值时告警';
+COMMENT ON COLUMN quotapool_alert_rule.webhook_url IS 'Webhook 地址';
+COMMENT ON COLUMN quotapool_alert_rule.secret IS '签名密钥，用于 HMAC-SHA256 签名';
+COMMENT ON COLUMN quotapool_alert_rule.enabled IS '是否启用';
+COMMENT ON COLUMN quotapool_alert_rule.triggered IS '是否已经触发，余额回到阈值以上后重置，保证每次跌破只告警一次';
+COMMENT ON COLUMN quotapool_alert_rule.last_triggered_at IS '上次触发时间';
+COMMENT ON COLUMN quotapool_alert_rule.created_at IS '创建时间';
+COMMENT ON COLUMN quotapool_alert_rule.updated_at IS '更新时间';
+
+CREATE TABLE quotapool_alert_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    rule_id BIGINT NOT NULL,
+    quota_pool_name VARCHAR(255) NOT NULL,
+    webhook_url VARCHAR(1024) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    response_status INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quotapool_alert_delivery_status_next_attempt_at ON quotapool_alert_delivery(status, next_attempt_at);
+CREATE INDEX idx_quotapool_alert_delivery_quota_pool_name ON quotapool_alert_delivery(quota_pool_name);
+
+COMMENT ON TABLE quotapool_alert_delivery IS '配额池告警 Webhook 投递记录';
+COMMENT ON COLUMN quotapool_alert_delivery.id IS '自增主键';
+COMMENT ON COLUMN quotapool_alert_delivery.event_id IS '事件 ID，接收方可用于去重';
+COMMENT ON COLUMN quotapool_alert_delivery.rule_id IS '告警规则 ID';
+COMMENT ON COLUMN quotapool_alert_delivery.quota_pool_name IS '配额池名称';
+COMMENT ON COLUMN quotapool_alert_delivery.webhook_url IS 'Webhook 地址';
+COMMENT ON COLUMN quotapool_alert_delivery.payload IS '推送内容';
+COMMENT ON COLUMN quotapool_alert_delivery.status IS '状态：pending | delivered | failed';
+COMMENT ON COLUMN quotapool_alert_delivery.attempts IS '已尝试次数';
+COMMENT ON COLUMN quotapool_alert_delivery.next_attempt_at IS '下次尝试时间';
+COMMENT ON COLUMN quotapool_alert_delivery.response_status IS '最近一次的 HTTP 响应状态码';
+COMMENT ON COLUMN quotapool_alert_delivery.last_error IS '最近一次的错误信息';
+COMMENT ON COLUMN quotapool_alert_delivery.delivered_at IS '投递成功时间';
+COMMENT ON COLUMN quotapool_alert_delivery.created_at IS '创建时间';
+COMMENT ON COLUMN quotapool_alert_delivery.updated_at IS '更新时间';
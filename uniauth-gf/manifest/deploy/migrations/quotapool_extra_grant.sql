@@ -0,0 +1,35 @@
+CREATE TABLE quotapool_extra_grant (
+    id BIGSERIAL PRIMARY KEY,
+    quota_pool_name VARCHAR(255) NOT NULL,
+    amount NUMERIC(25, 10) NOT NULL,
+    remaining NUMERIC(25, 10) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    origin VARCHAR(32) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    remark JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quotapool_extra_grant_quota_pool_name_status ON quotapool_extra_grant(quota_pool_name, status);
+CREATE INDEX idx_quotapool_extra_grant_status_expires_at ON quotapool_extra_grant(status, expires_at);
+
+COMMENT ON TABLE quotapool_extra_grant IS '加油包发放记录：配额池的 extra_quota 等于所有有效加油包的剩余额度之和';
+COMMENT ON COLUMN quotapool_extra_grant.id IS '自增主键';
+COMMENT ON COLUMN quotapool_extra_grant.quota_pool_name IS '配额池名称';
+COMMENT ON COLUMN quotapool_extra_grant.amount IS '发放额度';
+COMMENT ON COLUMN quotapool_extra_grant.remaining IS '剩余额度';
+COMMENT ON COLUMN quotapool_extra_grant.expires_at IS '过期时间，为空时永不过期';
+COMMENT ON COLUMN quotapool_extra_grant.status IS '状态：active | depleted | expired';
+COMMENT ON COLUMN quotapool_extra_grant.origin IS '来源：top_up | transfer | edit | legacy';
+COMMENT ON COLUMN quotapool_extra_grant.actor IS '操作者';
+COMMENT ON COLUMN quotapool_extra_grant.remark IS '备注信息';
+COMMENT ON COLUMN quotapool_extra_grant.created_at IS '创建时间';
+COMMENT ON COLUMN quotapool_extra_grant.updated_at IS '更新时间';
+
+-- 已有的加油包余额转为一条永不过期的加油包
+INSERT INTO quotapool_extra_grant (quota_pool_name, amount, remaining, origin, actor)
+SELECT quota_pool_name, extra_quota, extra_quota, 'legacy', 'system'
+FROM quotapool_quota_pool
+WHERE extra_quota > 0;
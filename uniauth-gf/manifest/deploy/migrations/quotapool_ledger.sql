@@ -0,0 +1,31 @@
+CREATE TABLE quotapool_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    quota_pool_name VARCHAR(255) NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    remaining_before NUMERIC(25, 10) NOT NULL,
+    remaining_after NUMERIC(25, 10) NOT NULL,
+    extra_before NUMERIC(25, 10) NOT NULL,
+    extra_after NUMERIC(25, 10) NOT NULL,
+    delta NUMERIC(25, 10) NOT NULL,
+    billing_record_id BIGINT,
+    remark JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quotapool_ledger_quota_pool_name_created_at ON quotapool_ledger(quota_pool_name, created_at);
+CREATE INDEX idx_quotapool_ledger_created_at ON quotapool_ledger(created_at);
+
+COMMENT ON TABLE quotapool_ledger IS '配额池账本：只追加的余额变动流水';
+COMMENT ON COLUMN quotapool_ledger.id IS '自增主键';
+COMMENT ON COLUMN quotapool_ledger.quota_pool_name IS '配额池名称';
+COMMENT ON COLUMN quotapool_ledger.reason IS '变动原因：charge | cron_reset | manual_reset | top_up | manual_adjust | rule_sync | transfer_in | transfer_out | grant_expire';
+COMMENT ON COLUMN quotapool_ledger.actor IS '操作者';
+COMMENT ON COLUMN quotapool_ledger.remaining_before IS '变动前剩余配额';
+COMMENT ON COLUMN quotapool_ledger.remaining_after IS '变动后剩余配额';
+COMMENT ON COLUMN quotapool_ledger.extra_before IS '变动前加油包';
+COMMENT ON COLUMN quotapool_ledger.extra_after IS '变动后加油包';
+COMMENT ON COLUMN quotapool_ledger.delta IS '可用余额（剩余配额 + 加油包）的变动量';
+COMMENT ON COLUMN quotapool_ledger.billing_record_id IS '扣费时对应的计费记录 ID';
+COMMENT ON COLUMN quotapool_ledger.remark IS '备注信息';
+COMMENT ON COLUMN quotapool_ledger.created_at IS '创建时间';
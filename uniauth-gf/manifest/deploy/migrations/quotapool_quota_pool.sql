@@ -7,12 +7,19 @@ CREATE TABLE quotapool_quota_pool (
     extra_quota NUMERIC(25, 10) NOT NULL DEFAULT 0,
     personal BOOLEAN NOT NULL,
     disabled BOOLEAN NOT NULL,
+    domain VARCHAR(64) NOT NULL DEFAULT 'default',
     userinfos_rules JSONB,
+    user_quota_limit NUMERIC(25, 10) NOT NULL DEFAULT -1,
+    user_limit_cron_cycle VARCHAR(255) NOT NULL DEFAULT '',
+    rollover_policy VARCHAR(32) NOT NULL DEFAULT 'discard',
+    rollover_cap_percent NUMERIC(25, 10) NOT NULL DEFAULT -1,
+    rollover_cap_amount NUMERIC(25, 10) NOT NULL DEFAULT -1,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
 );
 
 CREATE INDEX idx_quotapool_quota_pool_quota_pool_name ON quotapool_quota_pool(quota_pool_name);
+CREATE INDEX idx_quotapool_quota_pool_domain ON quotapool_quota_pool(domain);
 
 COMMENT ON COLUMN quotapool_quota_pool.quota_pool_name IS '配额池名称';
 COMMENT ON COLUMN quotapool_quota_pool.cron_cycle IS '刷新周期';
@@ -22,6 +29,12 @@ COMMENT ON COLUMN quotapool_quota_pool.last_reset_at IS '上次刷新时间';
 COMMENT ON COLUMN quotapool_quota_pool.extra_quota IS '加油包';
 COMMENT ON COLUMN quotapool_quota_pool.personal IS '是否个人配额池';
 COMMENT ON COLUMN quotapool_quota_pool.disabled IS '是否禁用';
+COMMENT ON COLUMN quotapool_quota_pool.domain IS '所属的 Casbin 域';
 COMMENT ON COLUMN quotapool_quota_pool.userinfos_rules IS 'ITTools规则';
+COMMENT ON COLUMN quotapool_quota_pool.user_quota_limit IS '每个用户每周期的消费上限，小于 0 时不限制';
+COMMENT ON COLUMN quotapool_quota_pool.user_limit_cron_cycle IS '用户消费上限的统计周期，为空时沿用配额池的刷新周期';
+COMMENT ON COLUMN quotapool_quota_pool.rollover_policy IS '余额结转策略：discard 清零 | carry_over 结余结转 | carry_debt 欠费结转';
+COMMENT ON COLUMN quotapool_quota_pool.rollover_cap_percent IS '结余结转上限，定期配额的百分比，小于 0 时不限制';
+COMMENT ON COLUMN quotapool_quota_pool.rollover_cap_amount IS '结余结转上限，绝对值，小于 0 时不限制';
 COMMENT ON COLUMN quotapool_quota_pool.created_at IS '创建时间';
 COMMENT ON COLUMN quotapool_quota_pool.updated_at IS '修改时间';
\ No newline at end of file
@@ -0,0 +1,31 @@
+CREATE TABLE quotapool_reservation (
+    reservation_id VARCHAR(64) NOT NULL PRIMARY KEY,
+    quota_pool_name VARCHAR(255) NOT NULL,
+    upn VARCHAR(255) NOT NULL,
+    svc VARCHAR(255) NOT NULL,
+    product VARCHAR(255) NOT NULL,
+    amount NUMERIC(25, 10) NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'held',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    actual_cost NUMERIC(25, 10),
+    billing_record_id BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quotapool_reservation_quota_pool_name_status ON quotapool_reservation(quota_pool_name, status);
+CREATE INDEX idx_quotapool_reservation_status_expires_at ON quotapool_reservation(status, expires_at);
+
+COMMENT ON TABLE quotapool_reservation IS '配额预留：长时间流式对话开始前预先冻结的额度';
+COMMENT ON COLUMN quotapool_reservation.reservation_id IS '预留 ID';
+COMMENT ON COLUMN quotapool_reservation.quota_pool_name IS '配额池名称';
+COMMENT ON COLUMN quotapool_reservation.upn IS 'UPN';
+COMMENT ON COLUMN quotapool_reservation.svc IS '服务名称';
+COMMENT ON COLUMN quotapool_reservation.product IS '产品名称';
+COMMENT ON COLUMN quotapool_reservation.amount IS '预留金额';
+COMMENT ON COLUMN quotapool_reservation.status IS '状态：held | committed | released | expired';
+COMMENT ON COLUMN quotapool_reservation.expires_at IS '过期时间，过期后预留自动失效';
+COMMENT ON COLUMN quotapool_reservation.actual_cost IS '提交时的实际费用';
+COMMENT ON COLUMN quotapool_reservation.billing_record_id IS '提交后对应的计费记录 ID';
+COMMENT ON COLUMN quotapool_reservation.created_at IS '创建时间';
+COMMENT ON COLUMN quotapool_reservation.updated_at IS '更新时间';
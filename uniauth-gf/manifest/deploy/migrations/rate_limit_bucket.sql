@@ -0,0 +1,12 @@
+CREATE TABLE rate_limit_bucket (
+    key VARCHAR(1024) PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rate_limit_bucket_updated_at ON rate_limit_bucket(updated_at);
+
+COMMENT ON TABLE rate_limit_bucket IS '限流令牌桶，rateLimit.store 为 postgres 时使用，多个实例共享同一组令牌桶';
+COMMENT ON COLUMN rate_limit_bucket.key IS '令牌桶标识：<调用方>|<接口路径>';
+COMMENT ON COLUMN rate_limit_bucket.tokens IS '上次更新时桶中剩余的令牌数';
+COMMENT ON COLUMN rate_limit_bucket.updated_at IS '上次更新时间，按这个时间补充令牌';
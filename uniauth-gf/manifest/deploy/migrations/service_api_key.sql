@@ -0,0 +1,34 @@
+CREATE TABLE service_api_key (
+    id BIGSERIAL PRIMARY KEY,
+    key_prefix VARCHAR(32) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    service VARCHAR(255) NOT NULL,
+    scopes VARCHAR(255)[] NOT NULL DEFAULT '{}',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(32) NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    rotated_from BIGINT,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_service_api_key_service ON service_api_key(service);
+
+COMMENT ON TABLE service_api_key IS '服务间调用的 API Key。每个 Key 绑定一个服务身份，服务在 Casbin 中的 subject 为 svc:<service>';
+COMMENT ON COLUMN service_api_key.id IS '自增主键';
+COMMENT ON COLUMN service_api_key.key_prefix IS 'Key 的前几位，用于识别，不能用于鉴权';
+COMMENT ON COLUMN service_api_key.key_hash IS 'Key 的 SHA-256 哈希（十六进制），明文只在创建时返回一次';
+COMMENT ON COLUMN service_api_key.service IS '服务身份，例如 chat-backend';
+COMMENT ON COLUMN service_api_key.scopes IS '允许调用的路由组，例如 {billing,auth}，* 表示不限制。与服务身份的 Casbin 权限同时生效';
+COMMENT ON COLUMN service_api_key.description IS '备注';
+COMMENT ON COLUMN service_api_key.status IS '状态：active | revoked';
+COMMENT ON COLUMN service_api_key.expires_at IS '过期时间，为空表示永不过期。轮换后旧 Key 在宽限期结束时过期';
+COMMENT ON COLUMN service_api_key.rotated_from IS '轮换前的 Key ID';
+COMMENT ON COLUMN service_api_key.last_used_at IS '最近使用时间，按分钟粒度更新';
+COMMENT ON COLUMN service_api_key.last_used_ip IS '最近使用的客户端 IP';
+COMMENT ON COLUMN service_api_key.created_by IS '创建者';
+COMMENT ON COLUMN service_api_key.created_at IS '创建时间';
+COMMENT ON COLUMN service_api_key.revoked_at IS '吊销时间';